@@ -0,0 +1,90 @@
+// Package blofin provides Environment, the set of REST and WebSocket URLs of a Blofin deployment.
+package blofin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// URLs of the Blofin environments
+const (
+	ProdRESTURL      = "https://openapi.blofin.com"
+	ProdPublicWSURL  = "wss://openapi.blofin.com/ws/public"
+	ProdPrivateWSURL = "wss://openapi.blofin.com/ws/private"
+
+	DemoRESTURL      = "https://demo-trading-openapi.blofin.com"
+	DemoPublicWSURL  = "wss://demo-trading-openapi.blofin.com/ws/public"
+	DemoPrivateWSURL = "wss://demo-trading-openapi.blofin.com/ws/private"
+)
+
+// ErrEnvironmentMismatch is returned by CheckEnvironments when clients belong to different environments,
+// and by clients using credentials created for another environment (see CheckCredentials).
+var ErrEnvironmentMismatch = errors.New("blofin: environments do not match")
+
+// Environment resolves the REST, public WebSocket and private WebSocket URLs of a Blofin deployment.
+// Construct REST and WebSocket clients from the same Environment (rest.WithEnvironment, ws.New) and
+// check them with CheckEnvironments. Each client keeps its own Environment; there is no global state.
+type Environment struct {
+	Name         string
+	RESTURL      string
+	PublicWSURL  string
+	PrivateWSURL string
+}
+
+// Known environments
+var (
+	Production = Environment{Name: "production", RESTURL: ProdRESTURL, PublicWSURL: ProdPublicWSURL, PrivateWSURL: ProdPrivateWSURL}
+	Demo       = Environment{Name: "demo", RESTURL: DemoRESTURL, PublicWSURL: DemoPublicWSURL, PrivateWSURL: DemoPrivateWSURL}
+)
+
+func (e Environment) String() string {
+	if e.Name == "" {
+		return "unknown environment"
+	}
+	return e.Name
+}
+
+// IsZero reports whether e is the zero Environment.
+func (e Environment) IsZero() bool {
+	return e == Environment{}
+}
+
+// EnvironmentOf returns the known environment (Production or Demo) that url belongs to.
+func EnvironmentOf(url string) (Environment, bool) {
+	url = strings.TrimSuffix(url, "/")
+	for _, env := range []Environment{Production, Demo} {
+		if url == env.RESTURL || url == env.PublicWSURL || url == env.PrivateWSURL {
+			return env, true
+		}
+	}
+	return Environment{}, false
+}
+
+// CheckCredentials returns an error wrapping ErrEnvironmentMismatch if credentials created for
+// credsEnv are used by a client of clientEnv. The rest and ws clients call it before every
+// authenticated request and login. A zero credsEnv (credentials without a declared environment)
+// is accepted in any environment.
+func CheckCredentials(credsEnv, clientEnv Environment) error {
+	if credsEnv.IsZero() || credsEnv == clientEnv {
+		return nil
+	}
+	return fmt.Errorf("%w: credentials for %s used with %s", ErrEnvironmentMismatch, credsEnv, clientEnv)
+}
+
+// CheckEnvironments returns an error wrapping ErrEnvironmentMismatch unless all envs are the same
+// known environment. Call it with the Environment of each client (rest.Client.Environment,
+// ws.Client.Environment, ws.Pool.Environment) when wiring REST and WebSocket clients together.
+// A client with a custom URL has the zero Environment and is refused: construct it from an
+// Environment holding the custom URLs instead.
+func CheckEnvironments(envs ...Environment) error {
+	for i, env := range envs {
+		if env.IsZero() {
+			return fmt.Errorf("%w: client %d has an unknown environment", ErrEnvironmentMismatch, i)
+		}
+		if env != envs[0] {
+			return fmt.Errorf("%w: %s and %s", ErrEnvironmentMismatch, envs[0], env)
+		}
+	}
+	return nil
+}
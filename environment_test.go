@@ -0,0 +1,70 @@
+package blofin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	custom := Environment{Name: "proxy", RESTURL: "http://127.0.0.1:8080"}
+	tests := []struct {
+		name      string
+		credsEnv  Environment
+		clientEnv Environment
+		wantErr   bool
+	}{
+		{"same", Demo, Demo, false},
+		{"undeclared", Environment{}, Production, false},
+		{"demo key with production", Demo, Production, true},
+		{"production key with demo", Production, Demo, true},
+		{"custom URL client", Production, Environment{}, true},
+		{"custom environment", custom, custom, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckCredentials(tt.credsEnv, tt.clientEnv)
+			if got := errors.Is(err, ErrEnvironmentMismatch); got != tt.wantErr {
+				t.Errorf("CheckCredentials(%v, %v) = %v", tt.credsEnv, tt.clientEnv, err)
+			}
+		})
+	}
+}
+
+func TestCheckEnvironments(t *testing.T) {
+	tests := []struct {
+		name    string
+		envs    []Environment
+		wantErr bool
+	}{
+		{"same", []Environment{Demo, Demo, Demo}, false},
+		{"mixed", []Environment{Demo, Production}, true},
+		{"unknown", []Environment{Production, {}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckEnvironments(tt.envs...)
+			if got := errors.Is(err, ErrEnvironmentMismatch); got != tt.wantErr {
+				t.Errorf("CheckEnvironments(%v) = %v", tt.envs, err)
+			}
+		})
+	}
+}
+
+func TestEnvironmentOf(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Environment
+		ok   bool
+	}{
+		{ProdRESTURL, Production, true},
+		{DemoPublicWSURL, Demo, true},
+		{DemoRESTURL + "/", Demo, true},
+		{"https://example.com", Environment{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := EnvironmentOf(tt.url)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("EnvironmentOf(%q) = %v, %v", tt.url, got, ok)
+		}
+	}
+}
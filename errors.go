@@ -0,0 +1,103 @@
+// Package blofin provides the definitions shared by the REST and WebSocket clients: environments and
+// the catalog of Blofin API error codes.
+//
+// API errors returned by the rest and ws packages match the sentinel errors of this package with
+// errors.Is, while keeping the original code and message:
+//
+//	if errors.Is(err, blofin.ErrInsufficientBalance) {
+//		// ...
+//	}
+package blofin
+
+import (
+	"errors"
+	"sync"
+)
+
+// Sentinel errors for known Blofin error codes
+var (
+	ErrRateLimited          = errors.New("blofin: too many requests")
+	ErrInvalidParameter     = errors.New("blofin: invalid parameter")
+	ErrInvalidAPIKey        = errors.New("blofin: invalid API key")
+	ErrAPIKeyExpired        = errors.New("blofin: API key expired")
+	ErrInvalidSignature     = errors.New("blofin: invalid signature")
+	ErrInvalidPassphrase    = errors.New("blofin: invalid passphrase")
+	ErrInvalidTimestamp     = errors.New("blofin: invalid or expired timestamp")
+	ErrIPNotAllowed         = errors.New("blofin: IP not allowed")
+	ErrPermissionDenied     = errors.New("blofin: API key has no permission")
+	ErrInsufficientBalance  = errors.New("blofin: insufficient balance")
+	ErrOrderNotFound        = errors.New("blofin: order not found")
+	ErrInstrumentSuspended  = errors.New("blofin: instrument suspended")
+	ErrInstrumentNotFound   = errors.New("blofin: instrument not found")
+	ErrOrderSizeExceeded    = errors.New("blofin: order size exceeds limit")
+	ErrOrderSizeTooSmall    = errors.New("blofin: order size below minimum")
+	ErrPositionModeMismatch = errors.New("blofin: position mode mismatch")
+	ErrLoginFailed          = errors.New("blofin: login failed")
+	ErrNotLoggedIn          = errors.New("blofin: not logged in")
+	ErrChannelNotFound      = errors.New("blofin: channel not found")
+	ErrServiceUnavailable   = errors.New("blofin: service unavailable")
+)
+
+// ErrSubscribeFailed is matched by every rejected WebSocket subscribe or unsubscribe request,
+// in addition to the sentinel error of its code.
+var ErrSubscribeFailed = errors.New("blofin: subscription failed")
+
+var (
+	codesMu sync.RWMutex
+	// codes maps REST and WS error codes to sentinel errors
+	codes = map[string]error{
+		"429": ErrRateLimited,
+
+		"152001": ErrInvalidParameter, // parameter cannot be empty
+		"152002": ErrInvalidParameter, // parameter error
+		"152003": ErrInvalidParameter, // either parameter is required
+		"152004": ErrInvalidParameter, // JSON syntax error
+		"152005": ErrInvalidParameter, // wrong or empty parameter
+		"152009": ErrInvalidParameter, // parameter value not allowed
+		"152010": ErrInvalidParameter, // parameter out of range
+
+		"152401": ErrInvalidAPIKey,
+		"152402": ErrAPIKeyExpired,
+		"152403": ErrInvalidSignature,
+		"152404": ErrInvalidPassphrase,
+		"152405": ErrIPNotAllowed,
+		"152406": ErrPermissionDenied,
+		"152408": ErrInvalidTimestamp,
+		"152409": ErrInvalidSignature,
+
+		"102002": ErrInsufficientBalance,
+		"102005": ErrOrderNotFound,
+		"102014": ErrOrderSizeExceeded, // limit order size exceeds maximum
+		"102015": ErrOrderSizeExceeded, // market order size exceeds maximum
+		"102022": ErrInstrumentNotFound,
+		"102035": ErrInstrumentSuspended,
+		"102038": ErrPositionModeMismatch,
+		"103003": ErrInsufficientBalance, // insufficient margin
+		"103013": ErrOrderNotFound,
+
+		"50001": ErrServiceUnavailable,
+		"60004": ErrInvalidTimestamp,
+		"60005": ErrInvalidAPIKey,
+		"60006": ErrInvalidTimestamp,
+		"60007": ErrInvalidSignature,
+		"60009": ErrLoginFailed,
+		"60011": ErrNotLoggedIn,
+		"60018": ErrChannelNotFound,
+		"60024": ErrInvalidPassphrase,
+	}
+)
+
+// ErrorForCode returns the sentinel error of a Blofin error code, or nil if the code is unknown.
+func ErrorForCode(code string) error {
+	codesMu.RLock()
+	defer codesMu.RUnlock()
+	return codes[code]
+}
+
+// RegisterErrorCode maps code to err, so that API errors with code match err with errors.Is.
+// It may add codes missing from the catalog or override existing mappings.
+func RegisterErrorCode(code string, err error) {
+	codesMu.Lock()
+	codes[code] = err
+	codesMu.Unlock()
+}
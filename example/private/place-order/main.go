@@ -0,0 +1,49 @@
+// Example for testing PlaceOrder and CancelOrder from the Blofin private API.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+	"github.com/mmavka/go-blofin/rest"
+)
+
+func main() {
+	client := rest.New(
+		rest.WithEnvironment(blofin.Demo), // Use demo trading for examples
+		rest.WithEnvCredentials(
+			blofin.Demo, // The key was created for demo trading
+			os.Getenv("BLOFIN_API_KEY"),
+			os.Getenv("BLOFIN_SECRET_KEY"),
+			os.Getenv("BLOFIN_PASSPHRASE"),
+		),
+	)
+
+	ctx := context.Background()
+	res, err := client.PlaceOrder(ctx, models.PlaceOrderRequest{
+		InstID:        "BTC-USDT",
+		MarginMode:    models.MarginModeCross,
+		PositionSide:  models.PositionSideNet,
+		Side:          models.TradeSideBuy,
+		OrderType:     models.OrderTypeLimit,
+		Price:         "10000",
+		Size:          "0.1",
+		ClientOrderID: "example1",
+	})
+	if err != nil {
+		slog.Error("failed to place order", "error", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Placed: %+v\n", res)
+
+	canceled, err := client.CancelOrder(ctx, models.CancelOrderRequest{OrderID: res.OrderID})
+	if err != nil {
+		slog.Error("failed to cancel order", "error", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Canceled: %+v\n", canceled)
+}
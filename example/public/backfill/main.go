@@ -0,0 +1,33 @@
+// Example for downloading a week of 1m candlesticks with Backfill from the Blofin public API.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+	"github.com/mmavka/go-blofin/rest"
+)
+
+func main() {
+	client := rest.NewClient() // Uses BaseURLProd by default
+
+	res, err := client.Backfill(context.Background(), rest.BackfillRequest{
+		InstID:          "BTC-USDT",
+		Bar:             models.Bar1m,
+		From:            time.Now().AddDate(0, 0, -7),
+		DropUnconfirmed: true,
+	})
+	if err != nil {
+		slog.Error("failed to backfill candlesticks", "error", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d candlesticks\n", len(res.Candles))
+	for _, gap := range res.Gaps {
+		fmt.Printf("gap: %s - %s\n", gap.From, gap.To)
+	}
+}
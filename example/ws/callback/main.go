@@ -16,7 +16,12 @@ func main() {
 	client := ws.NewClient(ws.WSURLProd)
 	errCh := make(chan error, 1)
 	client.SetErrorHandler(func(err error) {
-		errCh <- err
+		// Called from the read loop: never block it
+		select {
+		case errCh <- err:
+		default:
+			fmt.Println("error:", err)
+		}
 	})
 	ctx := context.Background()
 	if err := client.Connect(ctx); err != nil {
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mmavka/go-blofin/ws"
+)
+
+func main() {
+	client := ws.NewClient(ws.WSURLProd)
+	errCh := make(chan error, 1)
+	client.SetErrorHandler(func(err error) {
+		// Called from the read loop: never block it
+		select {
+		case errCh <- err:
+		default:
+			fmt.Println("error:", err)
+		}
+	})
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		fmt.Println("connect error:", err)
+		return
+	}
+	defer client.Close()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+
+	book, err := client.WatchOrderBook(ctx, ws.ChannelOrderBook, "BTC-USDT")
+	if err != nil {
+		fmt.Println("subscribe error:", err)
+		return
+	}
+	defer book.Close(ctx)
+
+	fmt.Println("Watching BTC-USDT order book. Press Ctrl+C to exit.")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			bid, okBid := book.BestBid()
+			ask, okAsk := book.BestAsk()
+			if okBid && okAsk {
+				fmt.Printf("seq=%d bid=%s@%s ask=%s@%s\n", book.SeqID(), bid.Quantity, bid.Price, ask.Quantity, ask.Price)
+			}
+		case <-ch:
+			return
+		case err := <-errCh:
+			fmt.Println("connection error:", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+	"github.com/mmavka/go-blofin/rest"
+	"github.com/mmavka/go-blofin/ws"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Track tickers of all perpetual swaps
+	instruments, err := rest.NewClient().GetInstruments(ctx, nil)
+	if err != nil {
+		fmt.Println("instruments error:", err)
+		return
+	}
+	instIDs := make([]string, 0, len(instruments))
+	for _, inst := range instruments {
+		instIDs = append(instIDs, inst.InstID)
+	}
+
+	pool := ws.NewPool(ws.WSURLProd)
+	pool.SetReconnectPolicy(ws.DefaultReconnectPolicy())
+	pool.SetErrorHandler(func(err error) {
+		fmt.Println("pool error:", err)
+	})
+	defer pool.Close()
+
+	subs, err := ws.PoolSubscribeAll(ctx, pool, ws.TickersChannel, instIDs, func(msg models.WSTickerMsg) {
+		for _, t := range msg.Data {
+			fmt.Printf("%s last=%s ask=%s bid=%s\n", t.InstID, t.Last, t.AskPrice, t.BidPrice)
+		}
+	})
+	if err != nil {
+		fmt.Println("subscribe error:", err)
+		return
+	}
+	stats := pool.Stats()
+	fmt.Printf("Subscribed to %d tickers over %d connections %v. Press Ctrl+C to exit.\n",
+		stats.Subscriptions, stats.Connections, stats.PerConnection)
+
+	// Unsubscribing frees capacity; the pool moves the rest onto fewer connections
+	go func() {
+		time.Sleep(30 * time.Second)
+		for _, sub := range subs[len(subs)/2:] {
+			sub.Unsubscribe()
+		}
+		time.Sleep(5 * time.Second)
+		stats := pool.Stats()
+		fmt.Printf("After unsubscribe: %d tickers over %d connections %v\n",
+			stats.Subscriptions, stats.Connections, stats.PerConnection)
+	}()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+	<-ch
+}
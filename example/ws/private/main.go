@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+	"github.com/mmavka/go-blofin/ws"
+)
+
+func main() {
+	client := ws.New(blofin.Demo, ws.WithCredentials(
+		os.Getenv("BLOFIN_API_KEY"),
+		os.Getenv("BLOFIN_SECRET_KEY"),
+		os.Getenv("BLOFIN_PASSPHRASE"),
+	))
+	errCh := make(chan error, 1)
+	client.SetErrorHandler(func(err error) {
+		// Called from the read loop: never block it
+		select {
+		case errCh <- err:
+		default:
+			fmt.Println("error:", err)
+		}
+	})
+	ctx := context.Background()
+	if err := client.Connect(ctx); err != nil {
+		var loginErr *ws.LoginError
+		if errors.As(err, &loginErr) {
+			fmt.Println("login rejected:", loginErr.Code, loginErr.Message)
+			return
+		}
+		fmt.Println("connect error:", err)
+		return
+	}
+	defer client.Close()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+
+	err := client.SubscribeOrders(ctx, "", func(msg models.WSOrderMsg) {
+		for _, o := range msg.Data {
+			fmt.Printf("order %s %s %s %s/%s %s\n", o.InstID, o.OrderID, o.Side, o.FilledSize, o.Size, o.State)
+		}
+	})
+	if err != nil {
+		fmt.Println("subscribe error:", err)
+		return
+	}
+
+	err = client.SubscribePositions(ctx, "", func(msg models.WSPositionMsg) {
+		for _, p := range msg.Data {
+			fmt.Printf("position %s %s %s pnl=%s\n", p.InstID, p.PositionSide, p.Positions, p.UnrealizedPnl)
+		}
+	})
+	if err != nil {
+		fmt.Println("subscribe error:", err)
+		return
+	}
+
+	fmt.Println("Subscribed to orders and positions. Press Ctrl+C to exit.")
+	select {
+	case <-ch:
+	case err := <-errCh:
+		fmt.Println("connection error:", err)
+	}
+}
@@ -1,6 +1,6 @@
 /**
  * @file: main.go
- * @description: Example of reconnect and resubscribe logic for ws client (handled by application)
+ * @description: Example of automatic reconnect and resubscribe with ws.ReconnectPolicy
  * @dependencies: github.com/mmavka/go-blofin/ws, github.com/mmavka/go-blofin/models
  * @created: 2024-06-14
  */
@@ -18,68 +18,52 @@ import (
 	"github.com/mmavka/go-blofin/ws"
 )
 
-type Subscription struct {
-	Channel string
-	InstID  string
-	Handler func(models.WSCandlestickMsg)
-}
-
 func main() {
 	// For graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt)
 
-	// List of active subscriptions
-	subscriptions := []Subscription{
-		{
-			Channel: ws.ChannelCandle1m,
-			InstID:  "BTC-USDT",
-			Handler: func(msg models.WSCandlestickMsg) {
-				fmt.Printf("BTC candle: %+v\n", msg)
-			},
-		},
-		{
-			Channel: ws.ChannelCandle1m,
-			InstID:  "ETH-USDT",
-			Handler: func(msg models.WSCandlestickMsg) {
-				fmt.Printf("ETH candle: %+v\n", msg)
-			},
-		},
-	}
+	ctx := context.Background()
+	client := ws.NewClient(ws.WSURLProd)
 
-	for {
-		ctx := context.Background()
-		client := ws.NewClient(ws.WSURLProd)
+	// Reconnect with exponential backoff; subscriptions are replayed automatically
+	policy := ws.DefaultReconnectPolicy()
+	policy.MaxAttempts = 10
+	client.SetReconnectPolicy(policy)
+	client.SetReconnectHandler(func(attempt int, err error) {
+		slog.Warn("connection lost, reconnecting...", "attempt", attempt, "error", err)
+	})
 
-		// Канал для обработки ошибок соединения
-		errCh := make(chan error, 1)
-		client.SetErrorHandler(func(err error) {
-			errCh <- err
-		})
-
-		err := client.Connect(ctx)
-		if err != nil {
-			slog.Error("connect error", "error", err)
-			continue
+	// Receives the first reported error, e.g. when all reconnect attempts have failed
+	errCh := make(chan error, 1)
+	client.SetErrorHandler(func(err error) {
+		// Called from the read loop: never block it
+		select {
+		case errCh <- err:
+		default:
+			slog.Warn("websocket error", "error", err)
 		}
+	})
 
-		// Subscribe to all channels
-		for _, sub := range subscriptions {
-			err := client.SubscribeCandlesticks(ctx, sub.Channel, sub.InstID, sub.Handler)
-			if err != nil {
-				slog.Error("subscribe error", "error", err)
-			}
-		}
+	if err := client.Connect(ctx); err != nil {
+		slog.Error("connect error", "error", err)
+		os.Exit(1)
+	}
+	defer client.Close()
 
-		select {
-		case <-sigCh:
-			slog.Info("shutting down...")
-			client.Close()
-			return
-		case err := <-errCh:
-			slog.Error("connection error, reconnecting...", "error", err)
-			client.Close()
-			continue
+	for _, instID := range []string{"BTC-USDT", "ETH-USDT"} {
+		err := client.SubscribeCandlesticks(ctx, ws.ChannelCandle1m, instID, func(msg models.WSCandlestickMsg) {
+			fmt.Printf("%s candle: %+v\n", msg.Arg.InstID, models.ParseWSCandlestickMsg(msg))
+		})
+		if err != nil {
+			slog.Error("subscribe error", "error", err)
 		}
 	}
+
+	select {
+	case <-sigCh:
+		slog.Info("shutting down...")
+	case err := <-errCh:
+		slog.Error("connection error", "error", err)
+	}
 }
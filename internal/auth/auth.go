@@ -0,0 +1,31 @@
+// Package auth implements Blofin API request signing shared by the REST and WebSocket clients.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Sign returns the signature of prehash: HMAC-SHA256 with secret, hex encoded and then base64 encoded.
+func Sign(secret, prehash string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(prehash))
+	return base64.StdEncoding.EncodeToString([]byte(hex.EncodeToString(mac.Sum(nil))))
+}
+
+// Timestamp returns the current time in milliseconds as a string.
+func Timestamp() string {
+	return strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// Nonce returns a random hex string used as request nonce.
+func Nonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,26 @@
+// Package backoff computes exponential backoff delays shared by the REST retry and WebSocket reconnect policies.
+package backoff
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Delay returns the delay before the given attempt (starting from 1): initial * multiplier^(attempt-1),
+// capped at maxDelay if positive, randomized by ±jitter (fraction of the delay, 0..1).
+// A multiplier below 1 is treated as 1.
+func Delay(attempt int, initial, maxDelay time.Duration, multiplier, jitter float64) time.Duration {
+	delay := float64(initial)
+	multiplier = max(multiplier, 1)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if maxDelay > 0 && delay >= float64(maxDelay) {
+			delay = float64(maxDelay)
+			break
+		}
+	}
+	if jitter > 0 {
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(max(delay, 0))
+}
@@ -0,0 +1,149 @@
+// Package models contains data structures for private account API payloads.
+//
+// This file defines structures for futures account balance, positions, leverage, margin and position mode.
+package models
+
+// Position modes
+const (
+	PositionModeNet       = "net_mode"        // one-way mode
+	PositionModeLongShort = "long_short_mode" // hedge mode
+)
+
+// Margin adjustment types
+const (
+	MarginAdjustAdd    = "add"
+	MarginAdjustReduce = "reduce"
+)
+
+// AccountBalance represents the futures account balance from Blofin account API.
+type AccountBalance struct {
+	Ts             string                 `json:"ts"` // ms
+	TotalEquity    Decimal                `json:"totalEquity"`
+	IsolatedEquity Decimal                `json:"isolatedEquity"`
+	Details        []AccountBalanceDetail `json:"details"`
+}
+
+// AccountBalanceDetail represents the balance of a single currency in the futures account.
+type AccountBalanceDetail struct {
+	Currency              string  `json:"currency"`
+	Equity                Decimal `json:"equity"`
+	Balance               Decimal `json:"balance"`
+	Ts                    string  `json:"ts"` // ms
+	IsolatedEquity        Decimal `json:"isolatedEquity"`
+	Available             Decimal `json:"available"`
+	AvailableEquity       Decimal `json:"availableEquity"`
+	Frozen                Decimal `json:"frozen"`
+	OrderFrozen           Decimal `json:"orderFrozen"`
+	EquityUsd             Decimal `json:"equityUsd"`
+	IsolatedUnrealizedPnl Decimal `json:"isolatedUnrealizedPnl"`
+	Bonus                 Decimal `json:"bonus"`
+}
+
+// Position represents an open position from Blofin account API.
+type Position struct {
+	PositionID         string  `json:"positionId"`
+	InstID             string  `json:"instId"`
+	InstType           string  `json:"instType"`
+	MarginMode         string  `json:"marginMode"`
+	PositionSide       string  `json:"positionSide"`
+	Adl                string  `json:"adl"`
+	Positions          Decimal `json:"positions"`
+	AvailablePositions Decimal `json:"availablePositions"`
+	AveragePrice       Decimal `json:"averagePrice"`
+	Margin             Decimal `json:"margin"`
+	MarkPrice          Decimal `json:"markPrice"`
+	MarginRatio        Decimal `json:"marginRatio"`
+	LiquidationPrice   Decimal `json:"liquidationPrice"`
+	UnrealizedPnl      Decimal `json:"unrealizedPnl"`
+	UnrealizedPnlRatio Decimal `json:"unrealizedPnlRatio"`
+	MaintenanceMargin  Decimal `json:"maintenanceMargin"`
+	Leverage           Decimal `json:"leverage"`
+	CreateTime         string  `json:"createTime"` // ms
+	UpdateTime         string  `json:"updateTime"` // ms
+}
+
+// PositionHistory represents a closed position from Blofin account API.
+type PositionHistory struct {
+	PositionID        string  `json:"positionId"`
+	InstID            string  `json:"instId"`
+	InstType          string  `json:"instType"`
+	MarginMode        string  `json:"marginMode"`
+	PositionSide      string  `json:"positionSide"`
+	Leverage          Decimal `json:"leverage"`
+	OpenAveragePrice  Decimal `json:"openAveragePrice"`
+	CloseAveragePrice Decimal `json:"closeAveragePrice"`
+	OpenPositions     Decimal `json:"openPositions"`
+	ClosePositions    Decimal `json:"closePositions"`
+	RealizedPnl       Decimal `json:"realizedPnl"`
+	Fee               Decimal `json:"fee"`
+	FundingFee        Decimal `json:"fundingFee"`
+	LiquidationPrice  Decimal `json:"liquidationPrice"`
+	State             string  `json:"state"`
+	CreateTime        string  `json:"createTime"` // ms
+	UpdateTime        string  `json:"updateTime"` // ms
+}
+
+// LeverageInfo represents leverage settings of an instrument.
+type LeverageInfo struct {
+	InstID       string  `json:"instId"`
+	Leverage     Decimal `json:"leverage"`
+	MarginMode   string  `json:"marginMode"`
+	PositionSide string  `json:"positionSide"`
+}
+
+// SetLeverageRequest is the body for POST /api/v1/account/set-leverage.
+type SetLeverageRequest struct {
+	InstID       string `json:"instId"`
+	Leverage     string `json:"leverage"`
+	MarginMode   string `json:"marginMode"`
+	PositionSide string `json:"positionSide,omitempty"` // required for isolated margin in hedge mode
+}
+
+// MarginModeInfo represents the account margin mode.
+type MarginModeInfo struct {
+	MarginMode string `json:"marginMode"`
+}
+
+// PositionModeInfo represents the account position mode.
+type PositionModeInfo struct {
+	PositionMode string `json:"positionMode"`
+}
+
+// AdjustMarginRequest is the body for POST /api/v1/account/adjust-position-margin.
+// Only applicable to isolated margin positions.
+type AdjustMarginRequest struct {
+	InstID       string `json:"instId"`
+	MarginMode   string `json:"marginMode"`
+	PositionSide string `json:"positionSide"`
+	Type         string `json:"type"` // MarginAdjustAdd or MarginAdjustReduce
+	Amount       string `json:"amount"`
+}
+
+// Bill types
+const (
+	BillTypeTrade       = "trade"
+	BillTypeFundingFee  = "funding_fee"
+	BillTypeRealizedPnl = "realized_pnl"
+	BillTypeFee         = "fee"
+	BillTypeTransfer    = "transfer"
+	BillTypeLiquidation = "liquidation"
+)
+
+// Bill represents a single futures account ledger entry from Blofin account API.
+type Bill struct {
+	BillID        string  `json:"billId"`
+	InstID        string  `json:"instId"`
+	Currency      string  `json:"currency"`
+	Type          string  `json:"type"`
+	SubType       string  `json:"subType"`
+	BalanceChange Decimal `json:"balanceChange"`
+	Balance       Decimal `json:"balance"`
+	Fee           Decimal `json:"fee"`
+	Pnl           Decimal `json:"pnl"`
+	Ts            string  `json:"ts"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging bills.
+func (b Bill) Cursor() string {
+	return b.BillID
+}
@@ -0,0 +1,154 @@
+// Package models contains data structures for private TP/SL and algo order payloads.
+//
+// This file defines request and response structures for take-profit/stop-loss and trigger orders.
+package models
+
+// Trigger price types
+const (
+	TriggerPriceTypeLast  = "last"
+	TriggerPriceTypeIndex = "index"
+	TriggerPriceTypeMark  = "mark"
+)
+
+// Algo order types
+const (
+	AlgoOrderTypeTrigger = "trigger"
+)
+
+// TP/SL and algo order states
+const (
+	AlgoStateLive      = "live"
+	AlgoStateEffective = "effective"
+	AlgoStateCanceled  = "canceled"
+	AlgoStateFailed    = "failed"
+)
+
+// PlaceTPSLRequest is the body for POST /api/v1/trade/order-tpsl.
+// At least one of TpTriggerPrice or SlTriggerPrice must be set.
+// An order price of "-1" means the TP/SL is executed at market price.
+type PlaceTPSLRequest struct {
+	InstID         string `json:"instId"`
+	MarginMode     string `json:"marginMode"`
+	PositionSide   string `json:"positionSide"`
+	Side           string `json:"side"`
+	TpTriggerPrice string `json:"tpTriggerPrice,omitempty"`
+	TpOrderPrice   string `json:"tpOrderPrice,omitempty"`
+	SlTriggerPrice string `json:"slTriggerPrice,omitempty"`
+	SlOrderPrice   string `json:"slOrderPrice,omitempty"`
+	Size           string `json:"size"` // "-1" closes the whole position
+	ReduceOnly     string `json:"reduceOnly,omitempty"`
+	ClientOrderID  string `json:"clientOrderId,omitempty"`
+	BrokerID       string `json:"brokerId,omitempty"`
+}
+
+// TPSLResult is a single result item returned by TP/SL placement and cancellation endpoints.
+type TPSLResult struct {
+	TpslID        string `json:"tpslId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Code          string `json:"code"`
+	Msg           string `json:"msg"`
+}
+
+// Status returns the code and message of the result item.
+func (r TPSLResult) Status() (code, msg string) {
+	return r.Code, r.Msg
+}
+
+// CancelTPSLRequest is a single item of the body for POST /api/v1/trade/cancel-tpsl.
+// Either TpslID or ClientOrderID is required.
+type CancelTPSLRequest struct {
+	InstID        string `json:"instId,omitempty"`
+	TpslID        string `json:"tpslId,omitempty"`
+	ClientOrderID string `json:"clientOrderId,omitempty"`
+}
+
+// TPSLOrder represents a TP/SL order from Blofin trading API.
+type TPSLOrder struct {
+	TpslID         string  `json:"tpslId"`
+	ClientOrderID  string  `json:"clientOrderId"`
+	InstID         string  `json:"instId"`
+	MarginMode     string  `json:"marginMode"`
+	PositionSide   string  `json:"positionSide"`
+	Side           string  `json:"side"`
+	OrderCategory  string  `json:"orderCategory"`
+	Size           Decimal `json:"size"`
+	Leverage       Decimal `json:"leverage"`
+	State          string  `json:"state"`
+	TpTriggerPrice Decimal `json:"tpTriggerPrice"`
+	TpOrderPrice   Decimal `json:"tpOrderPrice"`
+	SlTriggerPrice Decimal `json:"slTriggerPrice"`
+	SlOrderPrice   Decimal `json:"slOrderPrice"`
+	ReduceOnly     string  `json:"reduceOnly"`
+	BrokerID       string  `json:"brokerId"`
+	CreateTime     string  `json:"createTime"` // ms
+}
+
+// AttachAlgoOrder is a TP/SL attached to a trigger order.
+type AttachAlgoOrder struct {
+	TpTriggerPrice     string `json:"tpTriggerPrice,omitempty"`
+	TpOrderPrice       string `json:"tpOrderPrice,omitempty"`
+	TpTriggerPriceType string `json:"tpTriggerPriceType,omitempty"`
+	SlTriggerPrice     string `json:"slTriggerPrice,omitempty"`
+	SlOrderPrice       string `json:"slOrderPrice,omitempty"`
+	SlTriggerPriceType string `json:"slTriggerPriceType,omitempty"`
+}
+
+// PlaceAlgoOrderRequest is the body for POST /api/v1/trade/order-algo.
+// OrderPrice "-1" means the order is executed at market price once triggered.
+type PlaceAlgoOrderRequest struct {
+	InstID           string            `json:"instId"`
+	MarginMode       string            `json:"marginMode"`
+	PositionSide     string            `json:"positionSide"`
+	Side             string            `json:"side"`
+	Size             string            `json:"size"`
+	OrderType        string            `json:"orderType"` // AlgoOrderTypeTrigger
+	OrderPrice       string            `json:"orderPrice,omitempty"`
+	TriggerPrice     string            `json:"triggerPrice"`
+	TriggerPriceType string            `json:"triggerPriceType,omitempty"` // TriggerPriceTypeLast (default), Index or Mark
+	ReduceOnly       string            `json:"reduceOnly,omitempty"`
+	ClientOrderID    string            `json:"clientOrderId,omitempty"`
+	BrokerID         string            `json:"brokerId,omitempty"`
+	AttachAlgoOrders []AttachAlgoOrder `json:"attachAlgoOrders,omitempty"`
+}
+
+// AlgoOrderResult is a single result item returned by algo order placement and cancellation endpoints.
+type AlgoOrderResult struct {
+	AlgoID        string `json:"algoId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Code          string `json:"code"`
+	Msg           string `json:"msg"`
+}
+
+// Status returns the code and message of the result item.
+func (r AlgoOrderResult) Status() (code, msg string) {
+	return r.Code, r.Msg
+}
+
+// CancelAlgoOrderRequest is the body for POST /api/v1/trade/cancel-algo.
+// Either AlgoID or ClientOrderID is required.
+type CancelAlgoOrderRequest struct {
+	InstID        string `json:"instId,omitempty"`
+	AlgoID        string `json:"algoId,omitempty"`
+	ClientOrderID string `json:"clientOrderId,omitempty"`
+}
+
+// AlgoOrder represents a trigger (algo) order from Blofin trading API.
+type AlgoOrder struct {
+	AlgoID           string            `json:"algoId"`
+	ClientOrderID    string            `json:"clientOrderId"`
+	InstID           string            `json:"instId"`
+	MarginMode       string            `json:"marginMode"`
+	PositionSide     string            `json:"positionSide"`
+	Side             string            `json:"side"`
+	OrderType        string            `json:"orderType"`
+	Size             Decimal           `json:"size"`
+	Leverage         Decimal           `json:"leverage"`
+	State            string            `json:"state"`
+	OrderPrice       Decimal           `json:"orderPrice"`
+	TriggerPrice     Decimal           `json:"triggerPrice"`
+	TriggerPriceType string            `json:"triggerPriceType"`
+	ReduceOnly       string            `json:"reduceOnly"`
+	BrokerID         string            `json:"brokerId"`
+	AttachAlgoOrders []AttachAlgoOrder `json:"attachAlgoOrders"`
+	CreateTime       string            `json:"createTime"` // ms
+}
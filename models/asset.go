@@ -0,0 +1,113 @@
+// Package models contains data structures for private asset API payloads.
+//
+// This file defines structures for funding account balances, transfers, deposits and withdrawals.
+package models
+
+// Account types
+const (
+	AccountTypeFunding = "funding"
+	AccountTypeFutures = "futures"
+	AccountTypeCopy    = "copy_trading"
+	AccountTypeEarn    = "earn"
+	AccountTypeSpot    = "spot"
+)
+
+// Deposit states
+const (
+	DepositStatePending = "0"
+	DepositStateDone    = "1"
+	DepositStateFailed  = "2"
+)
+
+// Withdrawal states
+const (
+	WithdrawalStatePending    = "0"
+	WithdrawalStateCanceled   = "1"
+	WithdrawalStateFailed     = "2"
+	WithdrawalStateProcessing = "3"
+	WithdrawalStateDone       = "4"
+)
+
+// AssetBalance represents the balance of a single currency in an asset account.
+type AssetBalance struct {
+	Currency  string  `json:"currency"`
+	Balance   Decimal `json:"balance"`
+	Available Decimal `json:"available"`
+	Frozen    Decimal `json:"frozen"`
+	Bonus     Decimal `json:"bonus"`
+}
+
+// TransferRequest is the body for POST /api/v1/asset/transfer.
+// FromAccount and ToAccount use the AccountType* constants.
+type TransferRequest struct {
+	Currency    string `json:"currency"`
+	Amount      string `json:"amount"`
+	FromAccount string `json:"fromAccount"`
+	ToAccount   string `json:"toAccount"`
+	ClientID    string `json:"clientId,omitempty"`
+}
+
+// TransferResult is the response for POST /api/v1/asset/transfer.
+type TransferResult struct {
+	TransferID string `json:"transferId"`
+	ClientID   string `json:"clientId"`
+}
+
+// Transfer represents a funds transfer between accounts from Blofin asset API.
+type Transfer struct {
+	TransferID  string  `json:"transferId"`
+	ClientID    string  `json:"clientId"`
+	Currency    string  `json:"currency"`
+	Amount      Decimal `json:"amount"`
+	FromAccount string  `json:"fromAccount"`
+	ToAccount   string  `json:"toAccount"`
+	State       string  `json:"state"`
+	Ts          string  `json:"ts"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging transfer history.
+func (t Transfer) Cursor() string {
+	return t.TransferID
+}
+
+// Deposit represents a deposit record from Blofin asset API.
+type Deposit struct {
+	DepositID string  `json:"depositId"`
+	Currency  string  `json:"currency"`
+	Chain     string  `json:"chain"`
+	Address   string  `json:"address"`
+	Type      string  `json:"type"`
+	TxID      string  `json:"txId"`
+	Amount    Decimal `json:"amount"`
+	State     string  `json:"state"`
+	Confirm   string  `json:"confirm"`
+	Ts        string  `json:"ts"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging deposit history.
+func (d Deposit) Cursor() string {
+	return d.DepositID
+}
+
+// Withdrawal represents a withdrawal record from Blofin asset API.
+type Withdrawal struct {
+	WithdrawID  string  `json:"withdrawId"`
+	ClientID    string  `json:"clientId"`
+	Currency    string  `json:"currency"`
+	Chain       string  `json:"chain"`
+	Address     string  `json:"address"`
+	Type        string  `json:"type"`
+	TxID        string  `json:"txId"`
+	Amount      Decimal `json:"amount"`
+	Fee         Decimal `json:"fee"`
+	FeeCurrency string  `json:"feeCurrency"`
+	State       string  `json:"state"`
+	Tag         string  `json:"tag"`
+	Memo        string  `json:"memo"`
+	Ts          string  `json:"ts"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging withdrawal history.
+func (w Withdrawal) Cursor() string {
+	return w.WithdrawID
+}
@@ -0,0 +1,348 @@
+// Package models contains data structures for API payloads.
+//
+// This file defines Decimal, an exact decimal number used for prices, sizes, amounts and rates.
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExp bounds the exponent accepted by ParseDecimal, so that input like "1e2000000000"
+// cannot make String or arithmetic allocate huge numbers.
+const maxDecimalExp = 10000
+
+// Decimal is an exact decimal number: coef * 10^exp.
+// The zero value is 0. Decimals are immutable; arithmetic returns new values.
+// Compare decimals with Cmp or Equal, not ==.
+//
+// Decimals are encoded in JSON as strings, like Blofin numbers. An empty string or null decodes to 0.
+type Decimal struct {
+	coef *big.Int // nil means 0
+	exp  int32
+}
+
+// NewDecimal returns value * 10^exp.
+func NewDecimal(value int64, exp int32) Decimal {
+	return Decimal{coef: big.NewInt(value), exp: exp}
+}
+
+// DecimalFromInt returns value as a Decimal.
+func DecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+// DecimalFromFloat returns the shortest decimal representation of f.
+func DecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// ParseDecimal parses a decimal number such as "42", "-0.0015" or "1.5e-3".
+// Numbers with an exponent beyond ±10000 are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	str := s
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		exp, str = e, str[:i]
+	}
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		exp -= int64(len(str) - i - 1)
+		str = str[:i] + str[i+1:]
+	}
+	digits := strings.TrimLeft(str, "+-")
+	if digits == "" || len(str)-len(digits) > 1 || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if exp < -maxDecimalExp || exp > maxDecimalExp {
+		return Decimal{}, fmt.Errorf("decimal exponent out of range %q", s)
+	}
+	coef, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{coef: coef, exp: int32(exp)}, nil
+}
+
+// MustDecimal is like ParseDecimal but panics if s is not a valid decimal.
+func MustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// decimalFields parses the numeric fields of an array item, keeping the first error.
+type decimalFields struct {
+	err error
+}
+
+// parse parses s, returning 0 for empty input. Invalid input is recorded in f.err.
+func (f *decimalFields) parse(s string) Decimal {
+	if s == "" {
+		return Decimal{}
+	}
+	d, err := ParseDecimal(s)
+	if err != nil && f.err == nil {
+		f.err = err
+	}
+	return d
+}
+
+// String returns d in plain notation, keeping its scale (e.g. "0.0100").
+func (d Decimal) String() string {
+	if d.coef == nil {
+		return "0"
+	}
+	s := d.coef.String()
+	if d.exp >= 0 {
+		if d.coef.Sign() == 0 {
+			return "0"
+		}
+		return s + strings.Repeat("0", int(d.exp))
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	scale := int(-d.exp)
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Int64 returns the integer part of d (truncated toward zero). ok is false if it overflows int64.
+func (d Decimal) Int64() (v int64, ok bool) {
+	i := d.Truncate(0).rescale(0)
+	return i.Int64(), i.IsInt64()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+// Cmp compares d and d2 and returns -1, 0 or +1.
+func (d Decimal) Cmp(d2 Decimal) int {
+	exp := min(d.exp, d2.exp)
+	return d.rescale(exp).Cmp(d2.rescale(exp))
+}
+
+// Equal reports whether d and d2 are numerically equal ("1.50" equals "1.5").
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	exp := min(d.exp, d2.exp)
+	return Decimal{coef: new(big.Int).Add(d.rescale(exp), d2.rescale(exp)), exp: exp}
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	exp := min(d.exp, d2.exp)
+	return Decimal{coef: new(big.Int).Sub(d.rescale(exp), d2.rescale(exp)), exp: exp}
+}
+
+// Mul returns d * d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), d2.int()), exp: d.exp + d2.exp}
+}
+
+// Div returns d / d2 rounded half away from zero to places digits after the decimal point.
+// It panics if d2 is zero.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	if d2.IsZero() {
+		panic("models: decimal division by zero")
+	}
+	// d / d2 * 10^places = d.coef / d2.coef * 10^(d.exp - d2.exp + places)
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(d2.int())
+	if shift := int64(d.exp) - int64(d2.exp) + int64(places); shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: quoRound(num, den), exp: -places}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), exp: d.exp}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	if d.Sign() >= 0 {
+		return d
+	}
+	return d.Neg()
+}
+
+// Round rounds d half away from zero to places digits after the decimal point.
+func (d Decimal) Round(places int32) Decimal {
+	if d.exp >= -places {
+		return d
+	}
+	return Decimal{coef: quoRound(d.int(), pow10(int64(-places-d.exp))), exp: -places}
+}
+
+// Truncate drops the digits of d after places digits after the decimal point.
+func (d Decimal) Truncate(places int32) Decimal {
+	if d.exp >= -places {
+		return d
+	}
+	return Decimal{coef: new(big.Int).Quo(d.int(), pow10(int64(-places-d.exp))), exp: -places}
+}
+
+// RoundStep rounds d half away from zero to a multiple of step (e.g. a tick or lot size).
+// The result has the scale of step. d is returned unchanged if step is not positive.
+func (d Decimal) RoundStep(step Decimal) Decimal {
+	return d.step(step, func(a, b, q, r *big.Int) {
+		q.Set(quoRound(a, b))
+	})
+}
+
+// FloorStep rounds d down (toward negative infinity) to a multiple of step.
+// The result has the scale of step. d is returned unchanged if step is not positive.
+func (d Decimal) FloorStep(step Decimal) Decimal {
+	return d.step(step, func(a, b, q, r *big.Int) {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	})
+}
+
+// CeilStep rounds d up (toward positive infinity) to a multiple of step.
+// The result has the scale of step. d is returned unchanged if step is not positive.
+func (d Decimal) CeilStep(step Decimal) Decimal {
+	return d.step(step, func(a, b, q, r *big.Int) {
+		if r.Sign() > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	})
+}
+
+// IsMultipleOf reports whether d is a multiple of step. Any d is a multiple of a step that is not positive.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	return d.Equal(d.FloorStep(step))
+}
+
+// step divides d by step (truncated quotient q, remainder r), lets adjust round q and returns q * step.
+func (d Decimal) step(step Decimal, adjust func(a, b, q, r *big.Int)) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	exp := min(d.exp, step.exp)
+	a, b := d.rescale(exp), step.rescale(exp)
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	adjust(a, b, q, r)
+	return Decimal{coef: q.Mul(q, step.int()), exp: step.exp}
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, d.String()), nil
+}
+
+// UnmarshalJSON decodes d from a JSON string or number. "" and null decode to 0.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return err
+		}
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// int returns the coefficient of d (never nil).
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d for exponent exp (exp <= d.exp).
+func (d Decimal) rescale(exp int32) *big.Int {
+	if exp >= d.exp {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(int64(d.exp)-int64(exp)))
+}
+
+// pow10 returns 10^n.
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// quoRound returns num / den rounded half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// Round up when 2|r| >= |den|
+	r2 := new(big.Int).Abs(r)
+	r2.Lsh(r2, 1)
+	if r2.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // String of the result
+		wantErr bool
+	}{
+		{in: "42", want: "42"},
+		{in: "-0.0015", want: "-0.0015"},
+		{in: "+1.50", want: "1.50"},
+		{in: "0.0100", want: "0.0100"},
+		{in: ".5", want: "0.5"},
+		{in: "5.", want: "5"},
+		{in: "1.5e-3", want: "0.0015"},
+		{in: "1E3", want: "1000"},
+		{in: "-2.5e2", want: "-250"},
+		{in: "1.5e10000", want: ""},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "1..2", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "+-1", wantErr: true},
+		{in: "1-", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1,5", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "1e1.5", wantErr: true},
+		{in: "1e10001", wantErr: true},
+		{in: "1e-10001", wantErr: true},
+		{in: "1e2000000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDecimal(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && tt.want != "" && d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, d, tt.want)
+		}
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		d    Decimal
+		want string
+	}{
+		{Decimal{}, "0"},
+		{NewDecimal(0, 3), "0"},
+		{NewDecimal(0, -2), "0.00"},
+		{NewDecimal(12, 2), "1200"},
+		{NewDecimal(12, -1), "1.2"},
+		{NewDecimal(12, -2), "0.12"},
+		{NewDecimal(12, -4), "0.0012"},
+		{NewDecimal(-12, -4), "-0.0012"},
+		{NewDecimal(-125, -1), "-12.5"},
+		{DecimalFromInt(-7), "-7"},
+		{DecimalFromFloat(0.1), "0.1"},
+		{DecimalFromFloat(-1234.5678), "-1234.5678"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	tests := []struct {
+		d, step                  string
+		round, floor, ceil       string // RoundStep, FloorStep, CeilStep
+		places                   int32
+		roundPlaces, truncPlaces string // Round(places), Truncate(places)
+	}{
+		{d: "2.345", step: "0.01", round: "2.35", floor: "2.34", ceil: "2.35", places: 2, roundPlaces: "2.35", truncPlaces: "2.34"},
+		{d: "-2.345", step: "0.01", round: "-2.35", floor: "-2.35", ceil: "-2.34", places: 2, roundPlaces: "-2.35", truncPlaces: "-2.34"},
+		{d: "2.344", step: "0.01", round: "2.34", floor: "2.34", ceil: "2.35", places: 2, roundPlaces: "2.34", truncPlaces: "2.34"},
+		{d: "7", step: "5", round: "5", floor: "5", ceil: "10", places: 0, roundPlaces: "7", truncPlaces: "7"},
+		{d: "7.5", step: "5", round: "10", floor: "5", ceil: "10", places: 0, roundPlaces: "8", truncPlaces: "7"},
+		{d: "0.3", step: "0.1", round: "0.3", floor: "0.3", ceil: "0.3", places: 3, roundPlaces: "0.3", truncPlaces: "0.3"},
+		{d: "1.23", step: "0.25", round: "1.25", floor: "1.00", ceil: "1.25", places: 1, roundPlaces: "1.2", truncPlaces: "1.2"},
+		// Steps that are not positive leave d unchanged
+		{d: "1.23", step: "0", round: "1.23", floor: "1.23", ceil: "1.23", places: 1, roundPlaces: "1.2", truncPlaces: "1.2"},
+	}
+	for _, tt := range tests {
+		d, step := MustDecimal(tt.d), MustDecimal(tt.step)
+		checks := []struct {
+			name string
+			got  Decimal
+			want string
+		}{
+			{"RoundStep", d.RoundStep(step), tt.round},
+			{"FloorStep", d.FloorStep(step), tt.floor},
+			{"CeilStep", d.CeilStep(step), tt.ceil},
+			{"Round", d.Round(tt.places), tt.roundPlaces},
+			{"Truncate", d.Truncate(tt.places), tt.truncPlaces},
+		}
+		for _, c := range checks {
+			if c.got.String() != c.want {
+				t.Errorf("%s.%s(%s) = %s, want %s", tt.d, c.name, tt.step, c.got, c.want)
+			}
+		}
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{"1", "3", 4, "0.3333"},
+		{"2", "3", 2, "0.67"},
+		{"-2", "3", 2, "-0.67"},
+		{"10", "4", 0, "3"},
+		{"1.5", "0.5", 1, "3.0"},
+		{"100", "0.25", 0, "400"},
+	}
+	for _, tt := range tests {
+		if got := MustDecimal(tt.a).Div(MustDecimal(tt.b), tt.places); got.String() != tt.want {
+			t.Errorf("%s.Div(%s, %d) = %s, want %s", tt.a, tt.b, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string // re-encoded value
+		wantErr bool
+	}{
+		{in: `"123.4500"`, want: `"123.4500"`},
+		{in: `"-0.001"`, want: `"-0.001"`},
+		{in: `1.25`, want: `"1.25"`},
+		{in: `""`, want: `"0"`},
+		{in: `null`, want: `"0"`},
+		{in: `"1e-2"`, want: `"0.01"`},
+		{in: `"abc"`, wantErr: true},
+		{in: `"1e20000"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var v struct{ P Decimal }
+		err := json.Unmarshal([]byte(`{"P":`+tt.in+`}`), &v)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != `{"P":`+tt.want+`}` {
+			t.Errorf("round trip of %s = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
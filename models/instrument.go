@@ -0,0 +1,117 @@
+// Package models contains data structures for API payloads.
+//
+// This file implements the trading rules of an Instrument: tick and lot rounding, conversions between
+// contracts, base currency and quote notional, and order validation.
+package models
+
+import (
+	"fmt"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// conversionPlaces is the number of digits after the decimal point kept by conversions that divide.
+// Conversions dividing by a zero ContractValue or price return 0.
+const conversionPlaces = 18
+
+// Tradable reports whether orders can be placed on the instrument (state live, or unknown).
+func (i Instrument) Tradable() bool {
+	return i.State == "" || i.State == InstrumentStateLive
+}
+
+// Inverse reports whether the instrument is an inverse contract (contract value in quote currency).
+func (i Instrument) Inverse() bool {
+	return i.ContractType == ContractTypeInverse
+}
+
+// RoundPrice rounds price to the nearest multiple of TickSize.
+func (i Instrument) RoundPrice(price Decimal) Decimal {
+	return price.RoundStep(i.TickSize)
+}
+
+// RoundSize rounds size (in contracts) toward zero to a multiple of LotSize, so that the rounded
+// order never exceeds the requested size. Use RoundStep on LotSize to round to the nearest lot.
+func (i Instrument) RoundSize(size Decimal) Decimal {
+	if size.Sign() < 0 {
+		return size.CeilStep(i.LotSize)
+	}
+	return size.FloorStep(i.LotSize)
+}
+
+// ContractsToBase returns the base currency amount of contracts. price is only used by inverse contracts.
+func (i Instrument) ContractsToBase(contracts, price Decimal) Decimal {
+	if i.Inverse() {
+		return divOrZero(contracts.Mul(i.ContractValue), price)
+	}
+	return contracts.Mul(i.ContractValue)
+}
+
+// BaseToContracts returns the number of contracts for a base currency amount, not rounded to LotSize.
+// price is only used by inverse contracts.
+func (i Instrument) BaseToContracts(base, price Decimal) Decimal {
+	if i.Inverse() {
+		return divOrZero(base.Mul(price), i.ContractValue)
+	}
+	return divOrZero(base, i.ContractValue)
+}
+
+// ContractsToQuote returns the quote currency notional of contracts at price.
+func (i Instrument) ContractsToQuote(contracts, price Decimal) Decimal {
+	if i.Inverse() {
+		return contracts.Mul(i.ContractValue)
+	}
+	return contracts.Mul(i.ContractValue).Mul(price)
+}
+
+// QuoteToContracts returns the number of contracts for a quote currency notional at price,
+// not rounded to LotSize.
+func (i Instrument) QuoteToContracts(quote, price Decimal) Decimal {
+	if i.Inverse() {
+		return divOrZero(quote, i.ContractValue)
+	}
+	return divOrZero(quote, i.ContractValue.Mul(price))
+}
+
+// ValidateOrder checks an order of size contracts at price against the instrument rules: state,
+// MinSize, LotSize, MaxLimitSize or MaxMarketSize, and TickSize (price is ignored for market orders).
+// Errors match blofin.ErrInstrumentSuspended, ErrOrderSizeTooSmall, ErrOrderSizeExceeded or
+// ErrInvalidParameter with errors.Is.
+func (i Instrument) ValidateOrder(orderType string, price, size Decimal) error {
+	if !i.Tradable() {
+		return fmt.Errorf("%w: %s is %s", blofin.ErrInstrumentSuspended, i.InstID, i.State)
+	}
+	if size.Sign() <= 0 {
+		return fmt.Errorf("%w: size must be positive", blofin.ErrInvalidParameter)
+	}
+	if size.LessThan(i.MinSize) {
+		return fmt.Errorf("%w: size %s is below min size %s", blofin.ErrOrderSizeTooSmall, size, i.MinSize)
+	}
+	if !size.IsMultipleOf(i.LotSize) {
+		return fmt.Errorf("%w: size %s is not a multiple of lot size %s", blofin.ErrInvalidParameter, size, i.LotSize)
+	}
+	maxSize := i.MaxLimitSize
+	if orderType == OrderTypeMarket {
+		maxSize = i.MaxMarketSize
+	}
+	if maxSize.Sign() > 0 && size.GreaterThan(maxSize) {
+		return fmt.Errorf("%w: size %s exceeds max %s order size %s", blofin.ErrOrderSizeExceeded, size, orderType, maxSize)
+	}
+	if orderType == OrderTypeMarket {
+		return nil
+	}
+	if price.Sign() <= 0 {
+		return fmt.Errorf("%w: price must be positive", blofin.ErrInvalidParameter)
+	}
+	if !price.IsMultipleOf(i.TickSize) {
+		return fmt.Errorf("%w: price %s is not a multiple of tick size %s", blofin.ErrInvalidParameter, price, i.TickSize)
+	}
+	return nil
+}
+
+// divOrZero returns a / b with conversionPlaces digits, or 0 if b is 0.
+func divOrZero(a, b Decimal) Decimal {
+	if b.IsZero() {
+		return Decimal{}
+	}
+	return a.Div(b, conversionPlaces)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "testing"
+
+func TestInstrumentRoundSize(t *testing.T) {
+	tests := []struct {
+		lot, size, want string
+	}{
+		{"1", "3", "3"},
+		{"1", "3.9", "3"},
+		{"0.1", "0.19", "0.1"},
+		{"0.1", "0.05", "0.0"},
+		{"0.01", "-1.239", "-1.23"},
+		{"5", "14.99", "10"},
+		{"0", "1.23", "1.23"}, // no lot size
+	}
+	for _, tt := range tests {
+		inst := Instrument{LotSize: MustDecimal(tt.lot)}
+		if got := inst.RoundSize(MustDecimal(tt.size)); got.String() != tt.want {
+			t.Errorf("RoundSize(%s) with lot %s = %s, want %s", tt.size, tt.lot, got, tt.want)
+		}
+	}
+}
@@ -1,23 +1,29 @@
 // Package models contains data structures for API payloads.
 package models
 
+import (
+	"fmt"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
 // Instrument represents a trading instrument from Blofin public API.
 type Instrument struct {
-	InstID        string `json:"instId"`
-	BaseCurrency  string `json:"baseCurrency"`
-	QuoteCurrency string `json:"quoteCurrency"`
-	ContractValue string `json:"contractValue"`
-	ListTime      string `json:"listTime"`
-	ExpireTime    string `json:"expireTime"`
-	MaxLeverage   string `json:"maxLeverage"`
-	MinSize       string `json:"minSize"`
-	LotSize       string `json:"lotSize"`
-	TickSize      string `json:"tickSize"`
-	InstType      string `json:"instType"`
-	ContractType  string `json:"contractType"`
-	MaxLimitSize  string `json:"maxLimitSize"`
-	MaxMarketSize string `json:"maxMarketSize"`
-	State         string `json:"state"`
+	InstID        string  `json:"instId"`
+	BaseCurrency  string  `json:"baseCurrency"`
+	QuoteCurrency string  `json:"quoteCurrency"`
+	ContractValue Decimal `json:"contractValue"`
+	ListTime      string  `json:"listTime"`
+	ExpireTime    string  `json:"expireTime"`
+	MaxLeverage   Decimal `json:"maxLeverage"`
+	MinSize       Decimal `json:"minSize"`
+	LotSize       Decimal `json:"lotSize"`
+	TickSize      Decimal `json:"tickSize"`
+	InstType      string  `json:"instType"`
+	ContractType  string  `json:"contractType"`
+	MaxLimitSize  Decimal `json:"maxLimitSize"`
+	MaxMarketSize Decimal `json:"maxMarketSize"`
+	State         string  `json:"state"`
 }
 
 // Instrument types
@@ -47,26 +53,54 @@ type InstrumentsResponse struct {
 }
 
 // ApiError represents an error returned by the Blofin API.
+// Known codes match the sentinel errors of package blofin with errors.Is.
 type ApiError struct {
 	Code    string
 	Message string
 }
 
 func (e *ApiError) Error() string {
-	return e.Message
+	return fmt.Sprintf("%s (code %s)", e.Message, e.Code)
+}
+
+// Unwrap returns the sentinel error of the code (see blofin.ErrorForCode), or nil if it is unknown.
+func (e *ApiError) Unwrap() error {
+	return blofin.ErrorForCode(e.Code)
 }
 
 // Candlestick represents a single candlestick (OHLCV) from Blofin API.
 type Candlestick struct {
-	Ts             string `json:"ts"` // ms
-	Open           string `json:"open"`
-	High           string `json:"high"`
-	Low            string `json:"low"`
-	Close          string `json:"close"`
-	Volume         string `json:"volume"`
-	VolumeCurrency string `json:"volumeCurrency"`
-	VolumeQuote    string `json:"volumeQuote"`
-	Confirm        string `json:"confirm"` // 0 = uncompleted, 1 = completed
+	Ts             string  `json:"ts"` // ms
+	Open           Decimal `json:"open"`
+	High           Decimal `json:"high"`
+	Low            Decimal `json:"low"`
+	Close          Decimal `json:"close"`
+	Volume         Decimal `json:"volume"`
+	VolumeCurrency Decimal `json:"volumeCurrency"`
+	VolumeQuote    Decimal `json:"volumeQuote"`
+	Confirm        string  `json:"confirm"` // 0 = uncompleted, 1 = completed
+}
+
+// ParseCandlestick parses a candlestick array
+// [ts, open, high, low, close, volume, volumeCurrency, volumeQuote, confirm] into Candlestick.
+// ok is false if arr is too short or holds an invalid number.
+func ParseCandlestick(arr []string) (Candlestick, bool) {
+	if len(arr) < 9 {
+		return Candlestick{}, false
+	}
+	var f decimalFields
+	candle := Candlestick{
+		Ts:             arr[0],
+		Open:           f.parse(arr[1]),
+		High:           f.parse(arr[2]),
+		Low:            f.parse(arr[3]),
+		Close:          f.parse(arr[4]),
+		Volume:         f.parse(arr[5]),
+		VolumeCurrency: f.parse(arr[6]),
+		VolumeQuote:    f.parse(arr[7]),
+		Confirm:        arr[8],
+	}
+	return candle, f.err == nil
 }
 
 // Bar sizes
@@ -96,25 +130,42 @@ const (
 
 // Ticker represents a ticker snapshot from Blofin public API.
 type Ticker struct {
-	InstID         string `json:"instId"`
-	Last           string `json:"last"`
-	LastSize       string `json:"lastSize"`
-	AskPrice       string `json:"askPrice"`
-	AskSize        string `json:"askSize"`
-	BidPrice       string `json:"bidPrice"`
-	BidSize        string `json:"bidSize"`
-	High24h        string `json:"high24h"`
-	Open24h        string `json:"open24h"`
-	Low24h         string `json:"low24h"`
-	VolCurrency24h string `json:"volCurrency24h"`
-	Vol24h         string `json:"vol24h"`
-	Ts             string `json:"ts"`
+	InstID         string  `json:"instId"`
+	Last           Decimal `json:"last"`
+	LastSize       Decimal `json:"lastSize"`
+	AskPrice       Decimal `json:"askPrice"`
+	AskSize        Decimal `json:"askSize"`
+	BidPrice       Decimal `json:"bidPrice"`
+	BidSize        Decimal `json:"bidSize"`
+	High24h        Decimal `json:"high24h"`
+	Open24h        Decimal `json:"open24h"`
+	Low24h         Decimal `json:"low24h"`
+	VolCurrency24h Decimal `json:"volCurrency24h"`
+	Vol24h         Decimal `json:"vol24h"`
+	Ts             string  `json:"ts"`
 }
 
 // OrderBookLevel represents a single price level in the order book.
 type OrderBookLevel struct {
-	Price    string `json:"price"`
-	Quantity string `json:"quantity"`
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
+}
+
+// ParseOrderBookLevel parses a [price, size] array into OrderBookLevel.
+// ok is false if arr is too short or holds an invalid number.
+func ParseOrderBookLevel(arr []string) (OrderBookLevel, bool) {
+	if len(arr) < 2 {
+		return OrderBookLevel{}, false
+	}
+	price, err := ParseDecimal(arr[0])
+	if err != nil {
+		return OrderBookLevel{}, false
+	}
+	quantity, err := ParseDecimal(arr[1])
+	if err != nil {
+		return OrderBookLevel{}, false
+	}
+	return OrderBookLevel{Price: price, Quantity: quantity}, true
 }
 
 // OrderBook represents the order book snapshot from Blofin public API.
@@ -126,12 +177,12 @@ type OrderBook struct {
 
 // Trade represents a recent transaction from Blofin public API.
 type Trade struct {
-	TradeID string `json:"tradeId"`
-	InstID  string `json:"instId"`
-	Price   string `json:"price"`
-	Size    string `json:"size"`
-	Side    string `json:"side"`
-	Ts      string `json:"ts"`
+	TradeID string  `json:"tradeId"`
+	InstID  string  `json:"instId"`
+	Price   Decimal `json:"price"`
+	Size    Decimal `json:"size"`
+	Side    string  `json:"side"`
+	Ts      string  `json:"ts"`
 }
 
 // Trade sides
@@ -142,15 +193,15 @@ const (
 
 // MarkPrice represents index and mark price from Blofin public API.
 type MarkPrice struct {
-	InstID     string `json:"instId"`
-	IndexPrice string `json:"indexPrice"`
-	MarkPrice  string `json:"markPrice"`
-	Ts         string `json:"ts"`
+	InstID     string  `json:"instId"`
+	IndexPrice Decimal `json:"indexPrice"`
+	MarkPrice  Decimal `json:"markPrice"`
+	Ts         string  `json:"ts"`
 }
 
 // FundingRate represents funding rate info from Blofin public API.
 type FundingRate struct {
-	InstID      string `json:"instId"`
-	FundingRate string `json:"fundingRate"`
-	FundingTime string `json:"fundingTime"`
+	InstID      string  `json:"instId"`
+	FundingRate Decimal `json:"fundingRate"`
+	FundingTime string  `json:"fundingTime"`
 }
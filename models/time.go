@@ -0,0 +1,94 @@
+// Package models contains data structures for API payloads.
+//
+// This file defines conversions of Blofin millisecond timestamps and time.Time accessors for Ts fields.
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// ParseMillis converts a millisecond Unix timestamp string into time.Time.
+// Returns the zero time if ms is empty or not a number.
+func ParseMillis(ms string) time.Time {
+	v, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(v)
+}
+
+// FormatMillis returns t as a millisecond Unix timestamp string, e.g. for before/after params.
+func FormatMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// Time returns the opening time of the candlestick.
+func (c Candlestick) Time() time.Time {
+	return ParseMillis(c.Ts)
+}
+
+// Time returns the opening time of the candlestick.
+func (c WSCandle) Time() time.Time {
+	return ParseMillis(c.Ts)
+}
+
+// Time returns the time of the ticker snapshot.
+func (t Ticker) Time() time.Time {
+	return ParseMillis(t.Ts)
+}
+
+// Time returns the time of the order book snapshot.
+func (ob OrderBook) Time() time.Time {
+	return ParseMillis(ob.Ts)
+}
+
+// Time returns the time of the trade.
+func (t Trade) Time() time.Time {
+	return ParseMillis(t.Ts)
+}
+
+// Time returns the time of the mark price.
+func (m MarkPrice) Time() time.Time {
+	return ParseMillis(m.Ts)
+}
+
+// Time returns the next funding time.
+func (f FundingRate) Time() time.Time {
+	return ParseMillis(f.FundingTime)
+}
+
+// Time returns the time of the fill.
+func (f Fill) Time() time.Time {
+	return ParseMillis(f.Ts)
+}
+
+// Time returns the update time of the account balance.
+func (b AccountBalance) Time() time.Time {
+	return ParseMillis(b.Ts)
+}
+
+// Time returns the update time of the currency balance.
+func (d AccountBalanceDetail) Time() time.Time {
+	return ParseMillis(d.Ts)
+}
+
+// Time returns the time of the bill.
+func (b Bill) Time() time.Time {
+	return ParseMillis(b.Ts)
+}
+
+// Time returns the time of the transfer.
+func (t Transfer) Time() time.Time {
+	return ParseMillis(t.Ts)
+}
+
+// Time returns the time of the deposit.
+func (d Deposit) Time() time.Time {
+	return ParseMillis(d.Ts)
+}
+
+// Time returns the time of the withdrawal.
+func (w Withdrawal) Time() time.Time {
+	return ParseMillis(w.Ts)
+}
@@ -0,0 +1,132 @@
+// Package models contains data structures for private trading API payloads.
+//
+// This file defines request and response structures for order placement, cancellation and queries.
+package models
+
+// Margin modes
+const (
+	MarginModeCross    = "cross"
+	MarginModeIsolated = "isolated"
+)
+
+// Position sides
+const (
+	PositionSideNet   = "net"
+	PositionSideLong  = "long"
+	PositionSideShort = "short"
+)
+
+// Order types
+const (
+	OrderTypeMarket   = "market"
+	OrderTypeLimit    = "limit"
+	OrderTypePostOnly = "post_only"
+	OrderTypeFOK      = "fok"
+	OrderTypeIOC      = "ioc"
+)
+
+// Order states
+const (
+	OrderStateLive            = "live"
+	OrderStatePartiallyFilled = "partially_filled"
+	OrderStateFilled          = "filled"
+	OrderStateCanceled        = "canceled"
+)
+
+// PlaceOrderRequest is the body for POST /api/v1/trade/order.
+// Side uses TradeSideBuy/TradeSideSell.
+type PlaceOrderRequest struct {
+	InstID         string `json:"instId"`
+	MarginMode     string `json:"marginMode"`
+	PositionSide   string `json:"positionSide"`
+	Side           string `json:"side"`
+	OrderType      string `json:"orderType"`
+	Price          string `json:"price,omitempty"`
+	Size           string `json:"size"`
+	ReduceOnly     string `json:"reduceOnly,omitempty"` // "true" or "false"
+	ClientOrderID  string `json:"clientOrderId,omitempty"`
+	TpTriggerPrice string `json:"tpTriggerPrice,omitempty"`
+	TpOrderPrice   string `json:"tpOrderPrice,omitempty"`
+	SlTriggerPrice string `json:"slTriggerPrice,omitempty"`
+	SlOrderPrice   string `json:"slOrderPrice,omitempty"`
+	BrokerID       string `json:"brokerId,omitempty"`
+}
+
+// OrderResult is a single result item returned by order placement and cancellation endpoints.
+type OrderResult struct {
+	OrderID       string `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Code          string `json:"code"`
+	Msg           string `json:"msg"`
+}
+
+// Status returns the code and message of the result item.
+func (r OrderResult) Status() (code, msg string) {
+	return r.Code, r.Msg
+}
+
+// CancelOrderRequest is the body for POST /api/v1/trade/cancel-order.
+// Either OrderID or ClientOrderID is required.
+type CancelOrderRequest struct {
+	InstID        string `json:"instId,omitempty"`
+	OrderID       string `json:"orderId,omitempty"`
+	ClientOrderID string `json:"clientOrderId,omitempty"`
+}
+
+// Order represents an order from Blofin trading API.
+type Order struct {
+	OrderID            string  `json:"orderId"`
+	ClientOrderID      string  `json:"clientOrderId"`
+	InstID             string  `json:"instId"`
+	MarginMode         string  `json:"marginMode"`
+	PositionSide       string  `json:"positionSide"`
+	Side               string  `json:"side"`
+	OrderType          string  `json:"orderType"`
+	Price              Decimal `json:"price"`
+	Size               Decimal `json:"size"`
+	ReduceOnly         string  `json:"reduceOnly"`
+	Leverage           Decimal `json:"leverage"`
+	State              string  `json:"state"`
+	FilledSize         Decimal `json:"filledSize"`
+	FilledAmount       Decimal `json:"filledAmount"`
+	AveragePrice       Decimal `json:"averagePrice"`
+	Fee                Decimal `json:"fee"`
+	Pnl                Decimal `json:"pnl"`
+	OrderCategory      string  `json:"orderCategory"`
+	TpTriggerPrice     Decimal `json:"tpTriggerPrice"`
+	TpOrderPrice       Decimal `json:"tpOrderPrice"`
+	SlTriggerPrice     Decimal `json:"slTriggerPrice"`
+	SlOrderPrice       Decimal `json:"slOrderPrice"`
+	CancelSource       string  `json:"cancelSource"`
+	CancelSourceReason string  `json:"cancelSourceReason"`
+	AlgoClientOrderID  string  `json:"algoClientOrderId"`
+	AlgoID             string  `json:"algoId"`
+	BrokerID           string  `json:"brokerId"`
+	CreateTime         string  `json:"createTime"` // ms
+	UpdateTime         string  `json:"updateTime"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging order history.
+func (o Order) Cursor() string {
+	return o.OrderID
+}
+
+// Fill represents a trade fill (transaction detail) from Blofin trading API.
+type Fill struct {
+	InstID       string  `json:"instId"`
+	TradeID      string  `json:"tradeId"`
+	OrderID      string  `json:"orderId"`
+	FillPrice    Decimal `json:"fillPrice"`
+	FillSize     Decimal `json:"fillSize"`
+	FillPnl      Decimal `json:"fillPnl"`
+	PositionSide string  `json:"positionSide"`
+	Side         string  `json:"side"`
+	Fee          Decimal `json:"fee"`
+	BrokerID     string  `json:"brokerId"`
+	Ts           string  `json:"ts"` // ms
+}
+
+// Cursor returns the value used with before/after params when paging fills history.
+func (f Fill) Cursor() string {
+	return f.TradeID
+}
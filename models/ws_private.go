@@ -0,0 +1,39 @@
+// Package models contains WebSocket message structures for private channels.
+//
+// This file defines structures for private WebSocket push messages (orders, algo orders, positions, account).
+package models
+
+// WSOrderMsg represents a push message from the orders channel.
+type WSOrderMsg struct {
+	Arg struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+	Data []Order `json:"data"`
+}
+
+// WSAlgoOrderMsg represents a push message from the orders-algo channel.
+type WSAlgoOrderMsg struct {
+	Arg struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+	Data []AlgoOrder `json:"data"`
+}
+
+// WSPositionMsg represents a push message from the positions channel.
+type WSPositionMsg struct {
+	Arg struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+	Data []Position `json:"data"`
+}
+
+// WSAccountMsg represents a push message from the account channel.
+type WSAccountMsg struct {
+	Arg struct {
+		Channel string `json:"channel"`
+	} `json:"arg"`
+	Data AccountBalance `json:"data"`
+}
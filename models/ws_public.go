@@ -3,22 +3,34 @@
 // This file defines structures for public WebSocket push messages (candlesticks, trades, tickers, etc.).
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // WSTradeMsg represents a push message from the trades channel.
+// Data items are decoded from objects or from arrays [tradeId, price, size, side, ts].
+// Data used to hold the raw arrays as [][]string; ParseWSTrade still converts a raw array.
 type WSTradeMsg struct {
 	Arg struct {
 		Channel string `json:"channel"`
 		InstID  string `json:"instId"`
 	} `json:"arg"`
-	Data [][]string `json:"data"` // [tradeId, price, size, side, ts]
+	Data []Trade `json:"data"`
 }
 
 // WSTickerMsg represents a push message from the tickers channel.
+// Data items are decoded from objects or from arrays
+// [last, lastSize, askPx, askSz, bidPx, bidSz, high24h, open24h, low24h, volCcy24h, vol24h, ts].
+// Data used to hold the raw arrays as [][]string; ParseWSTicker still converts a raw array.
 type WSTickerMsg struct {
 	Arg struct {
 		Channel string `json:"channel"`
 		InstID  string `json:"instId"`
 	} `json:"arg"`
-	Data [][]string `json:"data"` // [last, lastSize, askPx, askSz, bidPx, bidSz, high24h, open24h, low24h, volCcy24h, vol24h, ts]
+	Data []Ticker `json:"data"`
 }
 
 // WSOrderBookMsg represents a push message from the order book channel.
@@ -48,42 +60,47 @@ type WSCandlestickMsg struct {
 
 // WSCandle represents a single candlestick (OHLCV) from WS push.
 type WSCandle struct {
-	Ts               string // Opening time, ms
-	Open             string // Open price
-	High             string // Highest price
-	Low              string // Lowest price
-	Close            string // Close price
-	Vol              string // Trading volume (contracts)
-	VolCurrency      string // Trading volume (base currency)
-	VolCurrencyQuote string // Trading volume (quote currency)
-	Confirm          string // 0 = uncompleted, 1 = completed
+	Ts               string  // Opening time, ms
+	Open             Decimal // Open price
+	High             Decimal // Highest price
+	Low              Decimal // Lowest price
+	Close            Decimal // Close price
+	Vol              Decimal // Trading volume (contracts)
+	VolCurrency      Decimal // Trading volume (base currency)
+	VolCurrencyQuote Decimal // Trading volume (quote currency)
+	Confirm          string  // 0 = uncompleted, 1 = completed
 }
 
 // WSFundingRateMsg represents a push message from the funding rate channel.
+// Data items are decoded from objects or from arrays [fundingRate, fundingTime, instId].
+// Data used to hold the raw arrays as [][]string; ParseWSFundingRate still converts a raw array.
 type WSFundingRateMsg struct {
 	Arg struct {
 		Channel string `json:"channel"`
 		InstID  string `json:"instId"`
 	} `json:"arg"`
-	Data [][]string `json:"data"` // [fundingRate, fundingTime, instId]
+	Data []FundingRate `json:"data"`
 }
 
 // ParseWSCandle parses a string array from WS push into WSCandle struct.
+// ok is false if arr is too short or holds an invalid number.
 func ParseWSCandle(arr []string) (WSCandle, bool) {
 	if len(arr) < 9 {
 		return WSCandle{}, false
 	}
-	return WSCandle{
+	var f decimalFields
+	candle := WSCandle{
 		Ts:               arr[0],
-		Open:             arr[1],
-		High:             arr[2],
-		Low:              arr[3],
-		Close:            arr[4],
-		Vol:              arr[5],
-		VolCurrency:      arr[6],
-		VolCurrencyQuote: arr[7],
+		Open:             f.parse(arr[1]),
+		High:             f.parse(arr[2]),
+		Low:              f.parse(arr[3]),
+		Close:            f.parse(arr[4]),
+		Vol:              f.parse(arr[5]),
+		VolCurrency:      f.parse(arr[6]),
+		VolCurrencyQuote: f.parse(arr[7]),
 		Confirm:          arr[8],
-	}, true
+	}
+	return candle, f.err == nil
 }
 
 // ParseWSCandlestickMsg parses WSCandlestickMsg and returns a slice of WSCandle.
@@ -96,3 +113,179 @@ func ParseWSCandlestickMsg(msg WSCandlestickMsg) []WSCandle {
 	}
 	return candles
 }
+
+// ParseWSTrade parses a string array [tradeId, price, size, side, ts] into Trade (without InstID).
+// ok is false if arr is too short or holds an invalid number.
+func ParseWSTrade(arr []string) (Trade, bool) {
+	if len(arr) < 5 {
+		return Trade{}, false
+	}
+	var f decimalFields
+	trade := Trade{
+		TradeID: arr[0],
+		Price:   f.parse(arr[1]),
+		Size:    f.parse(arr[2]),
+		Side:    arr[3],
+		Ts:      arr[4],
+	}
+	return trade, f.err == nil
+}
+
+// ParseWSTicker parses a string array
+// [last, lastSize, askPx, askSz, bidPx, bidSz, high24h, open24h, low24h, volCcy24h, vol24h, ts]
+// into Ticker (without InstID). ok is false if arr is too short or holds an invalid number.
+func ParseWSTicker(arr []string) (Ticker, bool) {
+	if len(arr) < 12 {
+		return Ticker{}, false
+	}
+	var f decimalFields
+	ticker := Ticker{
+		Last:           f.parse(arr[0]),
+		LastSize:       f.parse(arr[1]),
+		AskPrice:       f.parse(arr[2]),
+		AskSize:        f.parse(arr[3]),
+		BidPrice:       f.parse(arr[4]),
+		BidSize:        f.parse(arr[5]),
+		High24h:        f.parse(arr[6]),
+		Open24h:        f.parse(arr[7]),
+		Low24h:         f.parse(arr[8]),
+		VolCurrency24h: f.parse(arr[9]),
+		Vol24h:         f.parse(arr[10]),
+		Ts:             arr[11],
+	}
+	return ticker, f.err == nil
+}
+
+// ParseWSFundingRate parses a string array [fundingRate, fundingTime, instId] into FundingRate.
+// ok is false if arr is too short or holds an invalid number.
+func ParseWSFundingRate(arr []string) (FundingRate, bool) {
+	if len(arr) < 2 {
+		return FundingRate{}, false
+	}
+	var f decimalFields
+	rate := FundingRate{FundingRate: f.parse(arr[0]), FundingTime: arr[1]}
+	if len(arr) > 2 {
+		rate.InstID = arr[2]
+	}
+	return rate, f.err == nil
+}
+
+// UnmarshalJSON decodes trades given as objects or arrays. Missing InstID is taken from Arg.
+func (m *WSTradeMsg) UnmarshalJSON(b []byte) error {
+	arg, data, err := decodeWSMsg(b, ParseWSTrade, func(v *Trade) *string { return &v.InstID })
+	if err != nil {
+		return err
+	}
+	m.Arg, m.Data = arg, data
+	return nil
+}
+
+// UnmarshalJSON decodes tickers given as objects or arrays. Missing InstID is taken from Arg.
+func (m *WSTickerMsg) UnmarshalJSON(b []byte) error {
+	arg, data, err := decodeWSMsg(b, ParseWSTicker, func(v *Ticker) *string { return &v.InstID })
+	if err != nil {
+		return err
+	}
+	m.Arg, m.Data = arg, data
+	return nil
+}
+
+// UnmarshalJSON decodes funding rates given as objects or arrays. Missing InstID is taken from Arg.
+func (m *WSFundingRateMsg) UnmarshalJSON(b []byte) error {
+	arg, data, err := decodeWSMsg(b, ParseWSFundingRate, func(v *FundingRate) *string { return &v.InstID })
+	if err != nil {
+		return err
+	}
+	m.Arg, m.Data = arg, data
+	return nil
+}
+
+// wsArg is the arg of a push message.
+type wsArg struct {
+	Channel string `json:"channel"`
+	InstID  string `json:"instId"`
+}
+
+// wsRawMsg is a push message with undecoded data.
+type wsRawMsg struct {
+	Arg  wsArg           `json:"arg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// decodeWSMsg decodes a push message whose data items are objects or arrays (see decodeWSData).
+// Items without an InstID, as returned by instID, take it from the arg.
+func decodeWSMsg[T any](b []byte, fromArray func([]string) (T, bool), instID func(*T) *string) (wsArg, []T, error) {
+	var raw wsRawMsg
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return wsArg{}, nil, err
+	}
+	data, err := decodeWSData(raw.Data, fromArray)
+	if err != nil {
+		return wsArg{}, nil, err
+	}
+	for i := range data {
+		if id := instID(&data[i]); *id == "" {
+			*id = raw.Arg.InstID
+		}
+	}
+	return raw.Arg, data, nil
+}
+
+// decodeWSData decodes push data: a list (or a single item) where each item is a JSON object
+// decoded into T or an array of strings/numbers converted with fromArray. Malformed arrays are
+// rejected with an error rather than reported with zero prices.
+func decodeWSData[T any](raw json.RawMessage, fromArray func([]string) (T, bool)) ([]T, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] == '{' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	data := make([]T, 0, len(items))
+	for _, item := range items {
+		item = bytes.TrimSpace(item)
+		if len(item) > 0 && item[0] == '[' {
+			var fields []json.RawMessage
+			if err := json.Unmarshal(item, &fields); err != nil {
+				return nil, err
+			}
+			arr := make([]string, len(fields))
+			for i, f := range fields {
+				var s string
+				if json.Unmarshal(f, &s) != nil {
+					s = strings.TrimSpace(string(f)) // number or literal
+				}
+				arr[i] = s
+			}
+			v, ok := fromArray(arr)
+			if !ok {
+				return nil, fmt.Errorf("malformed data item %s", item)
+			}
+			data = append(data, v)
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(item, &v); err != nil {
+			return nil, err
+		}
+		data = append(data, v)
+	}
+	return data, nil
+}
+
+// WSEventMsg represents an event message from the server (subscribe, unsubscribe, login, error, info).
+type WSEventMsg struct {
+	Event string `json:"event"`
+	Op    string `json:"op"` // request op echoed by some error events
+	Code  string `json:"code"`
+	Msg   string `json:"msg"`
+	Arg   struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+}
@@ -0,0 +1,166 @@
+// Package rest provides REST API client for private account endpoints.
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// GetAccountBalance fetches the futures account balance.
+func (c *Client) GetAccountBalance(ctx context.Context) (*models.AccountBalance, error) {
+	var balance models.AccountBalance
+	err := c.doSignedGet(ctx, EndpointAccountBalance, nil, &balance)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// GetPositions fetches open positions.
+// Params: instId (optional)
+func (c *Client) GetPositions(ctx context.Context, params url.Values) ([]models.Position, error) {
+	if err := validateParams(EndpointPositions, params); err != nil {
+		return nil, err
+	}
+	var positions []models.Position
+	err := c.doSignedGet(ctx, EndpointPositions, params, &positions)
+	if err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// GetPositionsHistory fetches closed positions.
+// Params: instId, positionId, before, after, limit (optional)
+func (c *Client) GetPositionsHistory(ctx context.Context, params url.Values) ([]models.PositionHistory, error) {
+	if err := validateParams(EndpointPositionsHistory, params); err != nil {
+		return nil, err
+	}
+	var positions []models.PositionHistory
+	err := c.doSignedGet(ctx, EndpointPositionsHistory, params, &positions)
+	if err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// GetLeverageInfo fetches leverage of an instrument for a margin mode.
+// Params: instId (required), marginMode (required)
+func (c *Client) GetLeverageInfo(ctx context.Context, params url.Values) (*models.LeverageInfo, error) {
+	if err := validateParams(EndpointLeverageInfo, params); err != nil {
+		return nil, err
+	}
+	var info models.LeverageInfo
+	err := c.doSignedGet(ctx, EndpointLeverageInfo, params, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// SetLeverage sets leverage of an instrument.
+// Required: instId, leverage, marginMode.
+func (c *Client) SetLeverage(ctx context.Context, req models.SetLeverageRequest) (*models.LeverageInfo, error) {
+	if req.InstID == "" {
+		return nil, errors.New("instId is required")
+	}
+	if req.Leverage == "" {
+		return nil, errors.New("leverage is required")
+	}
+	if req.MarginMode == "" {
+		return nil, errors.New("marginMode is required")
+	}
+	var info models.LeverageInfo
+	err := c.doSignedPost(ctx, EndpointSetLeverage, req, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetMarginMode fetches the account margin mode (cross or isolated).
+func (c *Client) GetMarginMode(ctx context.Context) (*models.MarginModeInfo, error) {
+	var info models.MarginModeInfo
+	err := c.doSignedGet(ctx, EndpointMarginMode, nil, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// SetMarginMode sets the account margin mode.
+// marginMode - models.MarginModeCross or models.MarginModeIsolated.
+func (c *Client) SetMarginMode(ctx context.Context, marginMode string) (*models.MarginModeInfo, error) {
+	if marginMode != models.MarginModeCross && marginMode != models.MarginModeIsolated {
+		return nil, errors.New("marginMode must be cross or isolated")
+	}
+	var info models.MarginModeInfo
+	err := c.doSignedPost(ctx, EndpointSetMarginMode, models.MarginModeInfo{MarginMode: marginMode}, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetPositionMode fetches the account position mode (one-way or hedge).
+func (c *Client) GetPositionMode(ctx context.Context) (*models.PositionModeInfo, error) {
+	var info models.PositionModeInfo
+	err := c.doSignedGet(ctx, EndpointPositionMode, nil, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// SetPositionMode sets the account position mode.
+// positionMode - models.PositionModeNet (one-way) or models.PositionModeLongShort (hedge).
+func (c *Client) SetPositionMode(ctx context.Context, positionMode string) (*models.PositionModeInfo, error) {
+	if positionMode != models.PositionModeNet && positionMode != models.PositionModeLongShort {
+		return nil, errors.New("positionMode must be net_mode or long_short_mode")
+	}
+	var info models.PositionModeInfo
+	err := c.doSignedPost(ctx, EndpointSetPositionMode, models.PositionModeInfo{PositionMode: positionMode}, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// AdjustMargin adds or reduces margin of an isolated position.
+// Required: instId, marginMode, positionSide, type, amount.
+func (c *Client) AdjustMargin(ctx context.Context, req models.AdjustMarginRequest) error {
+	if req.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if req.MarginMode != models.MarginModeIsolated {
+		return errors.New("marginMode must be isolated")
+	}
+	if req.PositionSide == "" {
+		return errors.New("positionSide is required")
+	}
+	if req.Type != models.MarginAdjustAdd && req.Type != models.MarginAdjustReduce {
+		return errors.New("type must be add or reduce")
+	}
+	if req.Amount == "" {
+		return errors.New("amount is required")
+	}
+	return c.doSignedPost(ctx, EndpointAdjustMargin, req, nil)
+}
+
+// GetBills fetches futures account ledger entries (funding fees, realized PnL, fees, transfers).
+// Params: instId, currency, type, before, after, begin, end, limit (optional)
+// before/after take a billId cursor (see models.Bill.Cursor).
+func (c *Client) GetBills(ctx context.Context, params url.Values) ([]models.Bill, error) {
+	if err := validateParams(EndpointBills, params); err != nil {
+		return nil, err
+	}
+	var bills []models.Bill
+	err := c.doSignedGet(ctx, EndpointBills, params, &bills)
+	if err != nil {
+		return nil, err
+	}
+	return bills, nil
+}
@@ -0,0 +1,167 @@
+// Package rest provides REST API client for private TP/SL and algo order endpoints.
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// PlaceTPSL places a take-profit/stop-loss order for a position.
+// Required: instId, marginMode, positionSide, side, size and tpTriggerPrice or slTriggerPrice.
+func (c *Client) PlaceTPSL(ctx context.Context, req models.PlaceTPSLRequest) (*models.TPSLResult, error) {
+	if req.InstID == "" {
+		return nil, errors.New("instId is required")
+	}
+	if req.MarginMode == "" {
+		return nil, errors.New("marginMode is required")
+	}
+	if req.PositionSide == "" {
+		return nil, errors.New("positionSide is required")
+	}
+	if req.Side == "" {
+		return nil, errors.New("side is required")
+	}
+	if req.Size == "" {
+		return nil, errors.New("size is required")
+	}
+	if req.TpTriggerPrice == "" && req.SlTriggerPrice == "" {
+		return nil, errors.New("tpTriggerPrice or slTriggerPrice is required")
+	}
+	var results []models.TPSLResult
+	err := c.doSignedPost(ctx, EndpointPlaceTPSL, req, &results)
+	if err != nil {
+		return nil, err
+	}
+	return firstOrderResult(results)
+}
+
+// CancelTPSL cancels up to 20 TP/SL orders by tpslId or clientOrderId.
+// Results are returned per order and must be checked by Code.
+func (c *Client) CancelTPSL(ctx context.Context, reqs []models.CancelTPSLRequest) ([]models.TPSLResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.New("orders are required")
+	}
+	if len(reqs) > MaxBatchOrders {
+		return nil, errors.New("orders must be at most 20")
+	}
+	for _, req := range reqs {
+		if req.TpslID == "" && req.ClientOrderID == "" {
+			return nil, errors.New("tpslId or clientOrderId is required")
+		}
+	}
+	var results []models.TPSLResult
+	err := c.doSignedPost(ctx, EndpointCancelTPSL, reqs, &results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetActiveTPSL fetches pending TP/SL orders.
+// Params: instId, tpslId, clientOrderId, before, after, limit (optional)
+func (c *Client) GetActiveTPSL(ctx context.Context, params url.Values) ([]models.TPSLOrder, error) {
+	if err := validateParams(EndpointActiveTPSL, params); err != nil {
+		return nil, err
+	}
+	var orders []models.TPSLOrder
+	err := c.doSignedGet(ctx, EndpointActiveTPSL, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetTPSLHistory fetches completed TP/SL orders.
+// Params: instId, tpslId, clientOrderId, state, before, after, limit (optional)
+func (c *Client) GetTPSLHistory(ctx context.Context, params url.Values) ([]models.TPSLOrder, error) {
+	if err := validateParams(EndpointTPSLHistory, params); err != nil {
+		return nil, err
+	}
+	var orders []models.TPSLOrder
+	err := c.doSignedGet(ctx, EndpointTPSLHistory, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// PlaceAlgoOrder places a conditional trigger order.
+// Required: instId, marginMode, positionSide, side, size, orderType, triggerPrice.
+func (c *Client) PlaceAlgoOrder(ctx context.Context, req models.PlaceAlgoOrderRequest) (*models.AlgoOrderResult, error) {
+	if req.InstID == "" {
+		return nil, errors.New("instId is required")
+	}
+	if req.MarginMode == "" {
+		return nil, errors.New("marginMode is required")
+	}
+	if req.PositionSide == "" {
+		return nil, errors.New("positionSide is required")
+	}
+	if req.Side == "" {
+		return nil, errors.New("side is required")
+	}
+	if req.Size == "" {
+		return nil, errors.New("size is required")
+	}
+	if req.OrderType == "" {
+		return nil, errors.New("orderType is required")
+	}
+	if req.TriggerPrice == "" {
+		return nil, errors.New("triggerPrice is required")
+	}
+	switch req.TriggerPriceType {
+	case "", models.TriggerPriceTypeLast, models.TriggerPriceTypeIndex, models.TriggerPriceTypeMark:
+	default:
+		return nil, errors.New("triggerPriceType must be last, index or mark")
+	}
+	var results []models.AlgoOrderResult
+	err := c.doSignedPost(ctx, EndpointPlaceAlgoOrder, req, &results)
+	if err != nil {
+		return nil, err
+	}
+	return firstOrderResult(results)
+}
+
+// CancelAlgoOrder cancels a trigger order by algoId or clientOrderId.
+func (c *Client) CancelAlgoOrder(ctx context.Context, req models.CancelAlgoOrderRequest) (*models.AlgoOrderResult, error) {
+	if req.AlgoID == "" && req.ClientOrderID == "" {
+		return nil, errors.New("algoId or clientOrderId is required")
+	}
+	var results []models.AlgoOrderResult
+	err := c.doSignedPost(ctx, EndpointCancelAlgoOrder, req, &results)
+	if err != nil {
+		return nil, err
+	}
+	return firstOrderResult(results)
+}
+
+// GetActiveAlgoOrders fetches pending trigger orders.
+// Params: orderType (required), instId, algoId, clientOrderId, before, after, limit (optional)
+func (c *Client) GetActiveAlgoOrders(ctx context.Context, params url.Values) ([]models.AlgoOrder, error) {
+	if err := validateParams(EndpointActiveAlgoOrders, params); err != nil {
+		return nil, err
+	}
+	var orders []models.AlgoOrder
+	err := c.doSignedGet(ctx, EndpointActiveAlgoOrders, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetAlgoOrdersHistory fetches completed trigger orders.
+// Params: orderType (required), instId, algoId, clientOrderId, state, before, after, limit (optional)
+func (c *Client) GetAlgoOrdersHistory(ctx context.Context, params url.Values) ([]models.AlgoOrder, error) {
+	if err := validateParams(EndpointAlgoOrdersHistory, params); err != nil {
+		return nil, err
+	}
+	var orders []models.AlgoOrder
+	err := c.doSignedGet(ctx, EndpointAlgoOrdersHistory, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -0,0 +1,93 @@
+// Package rest provides REST API client for private asset endpoints.
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// GetAssetBalances fetches per-currency balances of an asset account.
+// Params: accountType (required, e.g. models.AccountTypeFunding), currency (optional)
+func (c *Client) GetAssetBalances(ctx context.Context, params url.Values) ([]models.AssetBalance, error) {
+	if err := validateParams(EndpointAssetBalances, params); err != nil {
+		return nil, err
+	}
+	var balances []models.AssetBalance
+	err := c.doSignedGet(ctx, EndpointAssetBalances, params, &balances)
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// Transfer moves funds between accounts (e.g. funding and futures).
+// Required: currency, amount, fromAccount, toAccount.
+func (c *Client) Transfer(ctx context.Context, req models.TransferRequest) (*models.TransferResult, error) {
+	if req.Currency == "" {
+		return nil, errors.New("currency is required")
+	}
+	if req.Amount == "" {
+		return nil, errors.New("amount is required")
+	}
+	if req.FromAccount == "" {
+		return nil, errors.New("fromAccount is required")
+	}
+	if req.ToAccount == "" {
+		return nil, errors.New("toAccount is required")
+	}
+	if req.FromAccount == req.ToAccount {
+		return nil, errors.New("fromAccount and toAccount must differ")
+	}
+	var result models.TransferResult
+	err := c.doSignedPost(ctx, EndpointTransfer, req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTransferHistory fetches funds transfer history.
+// Params: currency, fromAccount, toAccount, before, after, limit (optional)
+// before/after take a transferId cursor (see models.Transfer.Cursor).
+func (c *Client) GetTransferHistory(ctx context.Context, params url.Values) ([]models.Transfer, error) {
+	if err := validateParams(EndpointTransferHistory, params); err != nil {
+		return nil, err
+	}
+	var transfers []models.Transfer
+	err := c.doSignedGet(ctx, EndpointTransferHistory, params, &transfers)
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// GetDepositHistory fetches deposit history.
+// Params: currency, depositId, txId, state, before, after, limit (optional)
+func (c *Client) GetDepositHistory(ctx context.Context, params url.Values) ([]models.Deposit, error) {
+	if err := validateParams(EndpointDepositHistory, params); err != nil {
+		return nil, err
+	}
+	var deposits []models.Deposit
+	err := c.doSignedGet(ctx, EndpointDepositHistory, params, &deposits)
+	if err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+// GetWithdrawalHistory fetches withdrawal history.
+// Params: currency, withdrawId, txId, state, before, after, limit (optional)
+func (c *Client) GetWithdrawalHistory(ctx context.Context, params url.Values) ([]models.Withdrawal, error) {
+	if err := validateParams(EndpointWithdrawalHistory, params); err != nil {
+		return nil, err
+	}
+	var withdrawals []models.Withdrawal
+	err := c.doSignedGet(ctx, EndpointWithdrawalHistory, params, &withdrawals)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawals, nil
+}
@@ -0,0 +1,33 @@
+// Package rest provides request signing for Blofin private REST endpoints.
+package rest
+
+import (
+	"net/http"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/internal/auth"
+)
+
+// Credentials holds the API key, secret and passphrase used to sign private requests.
+type Credentials struct {
+	APIKey     string
+	SecretKey  string
+	Passphrase string
+	// Environment is the environment the API key was created for (zero if not declared).
+	// Private requests of a client in another environment are refused (see blofin.CheckCredentials).
+	Environment blofin.Environment
+}
+
+// sign sets the ACCESS-* authentication headers for a request.
+// The prehash string is requestPath + method + timestamp + nonce + body.
+func (cr *Credentials) sign(h http.Header, method, requestPath string, body []byte) {
+	timestamp := auth.Timestamp()
+	nonce := auth.Nonce()
+	signature := auth.Sign(cr.SecretKey, requestPath+method+timestamp+nonce+string(body))
+
+	h.Set("ACCESS-KEY", cr.APIKey)
+	h.Set("ACCESS-SIGN", signature)
+	h.Set("ACCESS-TIMESTAMP", timestamp)
+	h.Set("ACCESS-NONCE", nonce)
+	h.Set("ACCESS-PASSPHRASE", cr.Passphrase)
+}
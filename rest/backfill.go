@@ -0,0 +1,205 @@
+// Package rest provides Backfill, a downloader of long candlestick histories.
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// maxCandlesPerRequest is the limit of GetCandlesticks.
+const maxCandlesPerRequest = 1440
+
+// BackfillRequest describes the candlesticks to download.
+type BackfillRequest struct {
+	InstID          string    // required
+	Bar             string    // models.Bar* constant, default 1m
+	From            time.Time // required, inclusive
+	To              time.Time // exclusive; zero = now
+	Concurrency     int       // requests in flight, default 4
+	BarsPerRequest  int       // default and max 1440
+	DropUnconfirmed bool      // drop candlesticks that are not completed (the current bar)
+}
+
+// Gap is a time range without candlesticks: [From, To).
+type Gap struct {
+	From time.Time
+	To   time.Time
+}
+
+// BackfillResult holds the downloaded candlesticks, sorted by time without duplicates,
+// and the gaps where the exchange returned no data.
+type BackfillResult struct {
+	Candles []models.Candlestick
+	Gaps    []Gap
+}
+
+// Backfill downloads the candlesticks of an instrument in [req.From, req.To). The range is split into
+// requests of req.BarsPerRequest bars run with bounded concurrency; each request waits for the
+// client rate limiter. The first failed request cancels the others and its error is returned.
+func (c *Client) Backfill(ctx context.Context, req BackfillRequest) (*BackfillResult, error) {
+	if req.InstID == "" {
+		return nil, errors.New("instId is required")
+	}
+	if req.Bar == "" {
+		req.Bar = models.Bar1m
+	}
+	next, err := barStep(req.Bar)
+	if err != nil {
+		return nil, err
+	}
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if req.From.IsZero() || !req.From.Before(req.To) {
+		return nil, errors.New("from must be before to")
+	}
+	if req.Concurrency <= 0 {
+		req.Concurrency = 4
+	}
+	if req.BarsPerRequest <= 0 || req.BarsPerRequest > maxCandlesPerRequest {
+		req.BarsPerRequest = maxCandlesPerRequest
+	}
+
+	windows := backfillWindows(req.From, req.To, req.BarsPerRequest, next)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		byTs     = make(map[string]models.Candlestick)
+		jobs     = make(chan Gap)
+	)
+	for range min(req.Concurrency, len(windows)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				candles, err := c.Candlesticks(ctx, CandlesticksParams{
+					InstID: req.InstID,
+					Bar:    req.Bar,
+					After:  w.To,                          // older than To
+					Before: w.From.Add(-time.Millisecond), // newer than or at From
+					Limit:  req.BarsPerRequest,
+				})
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("candlesticks %s - %s: %w", w.From.UTC().Format(time.RFC3339), w.To.UTC().Format(time.RFC3339), err)
+						cancel()
+					}
+				} else {
+					for _, k := range candles {
+						byTs[k.Ts] = k
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, w := range windows {
+		select {
+		case jobs <- w:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res := &BackfillResult{Candles: make([]models.Candlestick, 0, len(byTs))}
+	for _, k := range byTs {
+		t := k.Time()
+		if t.Before(req.From) || !t.Before(req.To) {
+			continue
+		}
+		res.Candles = append(res.Candles, k)
+	}
+	slices.SortFunc(res.Candles, func(a, b models.Candlestick) int { return a.Time().Compare(b.Time()) })
+	// Dropped bars were returned by the exchange, so they are not gaps
+	res.Gaps = candleGaps(res.Candles, req.From, req.To, next)
+	if req.DropUnconfirmed {
+		res.Candles = slices.DeleteFunc(res.Candles, func(k models.Candlestick) bool {
+			return k.Confirm != models.CandlestickCompleted
+		})
+	}
+	return res, nil
+}
+
+// backfillWindows splits [from, to) into windows of bars bars; the last one ends at to.
+func backfillWindows(from, to time.Time, bars int, next func(time.Time) time.Time) []Gap {
+	var windows []Gap
+	for start := from; start.Before(to); {
+		end := start
+		for i := 0; i < bars && end.Before(to); i++ {
+			end = next(end)
+		}
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, Gap{From: start, To: end})
+		start = end
+	}
+	return windows
+}
+
+// candleGaps returns the ranges of [from, to) longer than a bar without candlesticks.
+func candleGaps(candles []models.Candlestick, from, to time.Time, next func(time.Time) time.Time) []Gap {
+	var gaps []Gap
+	expected := from // earliest time the next bar may open at without a gap
+	for _, k := range candles {
+		t := k.Time()
+		if !t.Before(next(expected)) {
+			gaps = append(gaps, Gap{From: expected, To: t})
+		}
+		expected = next(t)
+	}
+	if !next(expected).After(to) {
+		gaps = append(gaps, Gap{From: expected, To: to})
+	}
+	return gaps
+}
+
+// barStep returns the function advancing a bar opening time by one bar.
+func barStep(bar string) (func(time.Time) time.Time, error) {
+	if bar == models.Bar1M {
+		return func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }, nil
+	}
+	d, ok := barDurations[bar]
+	if !ok {
+		return nil, fmt.Errorf("unknown bar %q", bar)
+	}
+	return func(t time.Time) time.Time { return t.Add(d) }, nil
+}
+
+// barDurations holds the duration of fixed size bars.
+var barDurations = map[string]time.Duration{
+	models.Bar1m:  time.Minute,
+	models.Bar3m:  3 * time.Minute,
+	models.Bar5m:  5 * time.Minute,
+	models.Bar15m: 15 * time.Minute,
+	models.Bar30m: 30 * time.Minute,
+	models.Bar1H:  time.Hour,
+	models.Bar2H:  2 * time.Hour,
+	models.Bar4H:  4 * time.Hour,
+	models.Bar6H:  6 * time.Hour,
+	models.Bar8H:  8 * time.Hour,
+	models.Bar12H: 12 * time.Hour,
+	models.Bar1D:  24 * time.Hour,
+	models.Bar3D:  72 * time.Hour,
+	models.Bar1W:  7 * 24 * time.Hour,
+}
@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// backfillBase is the opening time of bar 0 in the backfill tests.
+var backfillBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// bar returns the opening time of minute bar i.
+func bar(i float64) time.Time {
+	return backfillBase.Add(time.Duration(i * float64(time.Minute)))
+}
+
+func TestBackfillWindows(t *testing.T) {
+	tests := []struct {
+		name     string
+		barSize  string
+		from, to time.Time
+		bars     int
+		want     []Gap
+	}{
+		{
+			name: "aligned", barSize: models.Bar1m, from: bar(0), to: bar(8), bars: 4,
+			want: []Gap{{bar(0), bar(4)}, {bar(4), bar(8)}},
+		},
+		{
+			name: "short last window", barSize: models.Bar1m, from: bar(0), to: bar(10), bars: 4,
+			want: []Gap{{bar(0), bar(4)}, {bar(4), bar(8)}, {bar(8), bar(10)}},
+		},
+		{
+			name: "to inside a bar", barSize: models.Bar1m, from: bar(0), to: bar(5.5), bars: 4,
+			want: []Gap{{bar(0), bar(4)}, {bar(4), bar(5.5)}},
+		},
+		{
+			name: "single window", barSize: models.Bar1m, from: bar(0), to: bar(3), bars: 1440,
+			want: []Gap{{bar(0), bar(3)}},
+		},
+		{
+			name: "monthly bars", barSize: models.Bar1M,
+			from: backfillBase, to: backfillBase.AddDate(0, 3, 0), bars: 2,
+			want: []Gap{{backfillBase, backfillBase.AddDate(0, 2, 0)}, {backfillBase.AddDate(0, 2, 0), backfillBase.AddDate(0, 3, 0)}},
+		},
+		{
+			name: "empty range", barSize: models.Bar1m, from: bar(5), to: bar(5), bars: 4,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := barStep(tt.barSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := backfillWindows(tt.from, tt.to, tt.bars, next)
+			if !equalGaps(got, tt.want) {
+				t.Errorf("windows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCandleGaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		candles  []float64 // opening times in minutes
+		from, to time.Time
+		want     []Gap
+	}{
+		{name: "complete", candles: []float64{0, 1, 2, 3}, from: bar(0), to: bar(4)},
+		{name: "missing bars inside", candles: []float64{0, 1, 5, 6, 8, 9}, from: bar(0), to: bar(10), want: []Gap{{bar(2), bar(5)}, {bar(7), bar(8)}}},
+		{name: "missing first bars", candles: []float64{2, 3}, from: bar(0), to: bar(4), want: []Gap{{bar(0), bar(2)}}},
+		{name: "missing last bars", candles: []float64{0, 1}, from: bar(0), to: bar(4), want: []Gap{{bar(2), bar(4)}}},
+		{name: "bar in progress at to is not a gap", candles: []float64{0, 1, 2}, from: bar(0), to: bar(3.5)},
+		{name: "no candles", from: bar(0), to: bar(3), want: []Gap{{bar(0), bar(3)}}},
+	}
+	next, _ := barStep(models.Bar1m)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candles := make([]models.Candlestick, len(tt.candles))
+			for i, m := range tt.candles {
+				candles[i] = models.Candlestick{Ts: models.FormatMillis(bar(m))}
+			}
+			if got := candleGaps(candles, tt.from, tt.to, next); !equalGaps(got, tt.want) {
+				t.Errorf("gaps = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	// Bars 0..99; 40..44 are missing and 99 is the unconfirmed current bar
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		after, _ := strconv.ParseInt(q.Get("after"), 10, 64)
+		before, _ := strconv.ParseInt(q.Get("before"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		rows := [][]string{}
+		for i := 99; i >= 0 && len(rows) < limit; i-- {
+			ts := bar(float64(i)).UnixMilli()
+			if i >= 40 && i < 45 || ts >= after || ts <= before {
+				continue
+			}
+			confirm := models.CandlestickCompleted
+			if i == 99 {
+				confirm = "0"
+			}
+			rows = append(rows, []string{strconv.FormatInt(ts, 10), "1", "1", "1", "1", "1", "1", "1", confirm})
+		}
+		b, _ := json.Marshal(map[string]any{"code": "0", "msg": "", "data": rows})
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithRateLimiter(nil), WithRetryPolicy(nil))
+	res, err := c.Backfill(context.Background(), BackfillRequest{
+		InstID:          "BTC-USDT",
+		From:            bar(10),
+		To:              bar(100),
+		BarsPerRequest:  7,
+		DropUnconfirmed: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 90 bars in range, 5 missing, 1 unconfirmed
+	if len(res.Candles) != 84 {
+		t.Errorf("got %d candles, want 84", len(res.Candles))
+	}
+	for i := 1; i < len(res.Candles); i++ {
+		if !res.Candles[i].Time().After(res.Candles[i-1].Time()) {
+			t.Fatalf("candles not sorted at %d", i)
+		}
+	}
+	if want := []Gap{{bar(40), bar(45)}}; !equalGaps(res.Gaps, want) {
+		t.Errorf("gaps = %v, want %v", res.Gaps, want)
+	}
+}
+
+// equalGaps reports whether a and b hold the same ranges.
+func equalGaps(a, b []Gap) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].From.Equal(b[i].From) || !a[i].To.Equal(b[i].To) {
+			return false
+		}
+	}
+	return true
+}
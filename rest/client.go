@@ -2,56 +2,187 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 
+	blofin "github.com/mmavka/go-blofin"
 	"github.com/mmavka/go-blofin/models"
 )
 
 // Client is a REST API client for Blofin.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	creds       *Credentials
+	limiter     *RateLimiter // nil disables client-side rate limiting
+	retryPolicy *RetryPolicy // nil disables retries
+	userAgent   string
+	header      http.Header // extra request headers
+	logger      *slog.Logger
+	env         blofin.Environment // zero if baseURL is not a known environment
+}
+
+// New creates a new REST API client configured by opts. Without options it calls BaseURLProd
+// with DefaultTimeout, the default rate limits and retry policy.
+func New(opts ...Option) *Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.logger == nil {
+		o.logger = slog.New(slog.DiscardHandler)
+	}
+	return &Client{
+		baseURL:     o.baseURL,
+		httpClient:  o.client(),
+		creds:       o.creds,
+		limiter:     o.limiter,
+		retryPolicy: o.retryPolicy,
+		userAgent:   o.userAgent,
+		header:      o.header,
+		logger:      o.logger,
+		env:         o.env,
+	}
+}
+
+// Environment returns the environment of the client, or the zero Environment if the base URL is not
+// a known environment. Pass it to blofin.CheckEnvironments to verify the pairing with WebSocket clients.
+func (c *Client) Environment() blofin.Environment {
+	return c.env
 }
 
 // NewClient creates a new REST API client. If no baseURL is provided, BaseURLProd is used.
 func NewClient(baseURL ...string) *Client {
-	url := BaseURLProd
 	if len(baseURL) > 0 && baseURL[0] != "" {
-		url = baseURL[0]
-	}
-	return &Client{
-		baseURL:    url,
-		httpClient: &http.Client{},
+		return New(WithBaseURL(baseURL[0]))
 	}
+	return New()
+}
+
+// NewClientWithCredentials creates a new REST API client that can call private endpoints.
+// If no baseURL is provided, BaseURLProd is used.
+func NewClientWithCredentials(apiKey, secretKey, passphrase string, baseURL ...string) *Client {
+	c := NewClient(baseURL...)
+	c.creds = &Credentials{APIKey: apiKey, SecretKey: secretKey, Passphrase: passphrase}
+	return c
+}
+
+// SetRateLimiter replaces the client-side rate limiter (nil disables it).
+// A limiter may be shared by clients using the same IP or API key.
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.limiter = l
+}
+
+// RateLimiter returns the client-side rate limiter (nil if disabled), e.g. to inspect its Usage.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
 }
 
 // doGet performs a GET request to the given path with query params and decodes the response into result.
 func (c *Client) doGet(ctx context.Context, path string, query url.Values, result interface{}) error {
-	endpoint := c.baseURL + path
+	return c.do(ctx, http.MethodGet, path, query, nil, false, result)
+}
+
+// doSignedGet performs a signed GET request to a private endpoint.
+func (c *Client) doSignedGet(ctx context.Context, path string, query url.Values, result interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, true, result)
+}
+
+// doSignedPost performs a signed POST request with a JSON body to a private endpoint.
+func (c *Client) doSignedPost(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.do(ctx, http.MethodPost, path, nil, body, true, result)
+}
+
+// do performs an HTTP request, signs it if required, and decodes the {code,msg,data} envelope into result.
+// GET requests failed with a transient error are retried according to the retry policy.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, signed bool, result interface{}) error {
+	requestPath := path
 	if len(query) > 0 {
-		endpoint += "?" + query.Encode()
+		requestPath += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode body: %w", err)
+		}
+	}
+	if signed {
+		if c.creds == nil {
+			return errors.New("credentials are required for private endpoints")
+		}
+		if err := blofin.CheckCredentials(c.creds.Environment, c.env); err != nil {
+			return err
+		}
+	}
+
+	for retry := 1; ; retry++ {
+		err := c.attempt(ctx, method, path, requestPath, payload, signed, result)
+		delay, ok := c.shouldRetry(method, retry, err)
+		if !ok {
+			return err
+		}
+		c.logger.WarnContext(ctx, "retrying request", "method", method, "path", path, "retry", retry, "delay", delay, "error", err)
+		if sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// attempt sends a request once. Failures are returned as *RequestError, except for rate limiter
+// waits aborted by ctx and undecodable data.
+func (c *Client) attempt(ctx context.Context, method, path, requestPath string, payload []byte, signed bool, result interface{}) error {
+	// Wait before signing: a request delayed by the limiter must not carry a stale timestamp
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx, endpointGroup(path)); err != nil {
+			return err
+		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+requestPath, bytes.NewReader(payload))
 	if err != nil {
 		return err
 	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if signed {
+		c.creds.sign(req.Header, method, requestPath, payload)
+	}
 
+	reqErr := &RequestError{Method: method, Endpoint: path}
 	resp, err := c.httpClient.Do(req)
+	c.logResponse(ctx, method, path, resp, err)
 	if err != nil {
-		return err
+		reqErr.Err = err
+		return reqErr
 	}
 	defer resp.Body.Close()
+	reqErr.StatusCode = resp.StatusCode
+	reqErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		reqErr.Err = err
+		return reqErr
 	}
+	reqErr.Body = bodySnippet(respBody)
 
 	// Unmarshal into a generic response
 	var apiResp struct {
@@ -59,12 +190,22 @@ func (c *Client) doGet(ctx context.Context, path string, query url.Values, resul
 		Msg  string          `json:"msg"`
 		Data json.RawMessage `json:"data"`
 	}
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return err
-	}
+	decodeErr := json.Unmarshal(respBody, &apiResp)
 
-	if apiResp.Code != CodeSuccess {
-		return &models.ApiError{Code: apiResp.Code, Message: apiResp.Msg}
+	switch {
+	case decodeErr == nil && apiResp.Code != "" && apiResp.Code != CodeSuccess:
+		reqErr.Code, reqErr.Message = apiResp.Code, apiResp.Msg
+		reqErr.Err = &models.ApiError{Code: apiResp.Code, Message: apiResp.Msg}
+		return reqErr
+	case !statusOK(resp.StatusCode):
+		reqErr.Err = errHTTPStatus
+		return reqErr
+	case decodeErr != nil:
+		reqErr.Err = fmt.Errorf("failed to decode response: %w", decodeErr)
+		return reqErr
+	case apiResp.Code != CodeSuccess:
+		reqErr.Err = errors.New("response has no code")
+		return reqErr
 	}
 
 	if result != nil {
@@ -75,3 +216,12 @@ func (c *Client) doGet(ctx context.Context, path string, query url.Values, resul
 
 	return nil
 }
+
+// logResponse logs the outcome of an HTTP request at debug level.
+func (c *Client) logResponse(ctx context.Context, method, path string, resp *http.Response, err error) {
+	if err != nil {
+		c.logger.DebugContext(ctx, "request failed", "method", method, "path", path, "error", err)
+		return
+	}
+	c.logger.DebugContext(ctx, "request", "method", method, "path", path, "status", resp.StatusCode)
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// newTestServer starts a server answering every request with body and returns its URL.
+func newTestServer(t *testing.T, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCredentialsEnvironment(t *testing.T) {
+	url := newTestServer(t, `{"code":"0","msg":"","data":[]}`)
+	local := blofin.Environment{Name: "local", RESTURL: url}
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"matching", []Option{WithEnvironment(local), WithEnvCredentials(local, "k", "s", "p")}, false},
+		{"undeclared", []Option{WithBaseURL(url), WithCredentials("k", "s", "p")}, false},
+		{"demo key with production", []Option{WithEnvironment(blofin.Production), WithEnvCredentials(blofin.Demo, "k", "s", "p")}, true},
+		{"key with custom URL", []Option{WithBaseURL(url), WithEnvCredentials(local, "k", "s", "p")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(append(tt.opts, WithRetryPolicy(nil))...)
+			_, err := c.GetPositions(context.Background(), nil)
+			if got := errors.Is(err, blofin.ErrEnvironmentMismatch); got != tt.wantErr {
+				t.Fatalf("err = %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWithBaseURLResetsEnvironment(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want blofin.Environment
+	}{
+		{"default", nil, blofin.Production},
+		{"demo URL", []Option{WithBaseURL(BaseURLDemo)}, blofin.Demo},
+		{"custom URL after environment", []Option{WithEnvironment(blofin.Demo), WithBaseURL("http://127.0.0.1")}, blofin.Environment{}},
+		{"environment after URL", []Option{WithBaseURL("http://127.0.0.1"), WithEnvironment(blofin.Demo)}, blofin.Demo},
+	}
+	for _, tt := range tests {
+		if got := New(tt.opts...).Environment(); got != tt.want {
+			t.Errorf("%s: Environment() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 // Package rest contains constants for Blofin API endpoints and settings.
 package rest
 
+import blofin "github.com/mmavka/go-blofin"
+
 const (
-	// Base URLs
-	BaseURLProd = "https://openapi.blofin.com"
-	BaseURLDemo = "https://demo-trading-openapi.blofin.com"
+	// Base URLs (see blofin.Production and blofin.Demo)
+	BaseURLProd = blofin.ProdRESTURL
+	BaseURLDemo = blofin.DemoRESTURL
 
 	// Public REST endpoints
 	EndpointInstruments     = "/api/v1/market/instruments"
@@ -16,9 +18,51 @@ const (
 	EndpointFundingRateHist = "/api/v1/market/funding-rate-history"
 	EndpointCandlesticks    = "/api/v1/market/candles"
 
+	// Trading REST endpoints (private)
+	EndpointPlaceOrder        = "/api/v1/trade/order"
+	EndpointPlaceBatchOrders  = "/api/v1/trade/batch-orders"
+	EndpointCancelOrder       = "/api/v1/trade/cancel-order"
+	EndpointCancelBatchOrders = "/api/v1/trade/cancel-batch-orders"
+	EndpointActiveOrders      = "/api/v1/trade/orders-pending"
+	EndpointOrderDetail       = "/api/v1/trade/order-detail"
+	EndpointOrdersHistory     = "/api/v1/trade/orders-history"
+	EndpointFillsHistory      = "/api/v1/trade/fills-history"
+
+	// TP/SL and algo REST endpoints (private)
+	EndpointPlaceTPSL         = "/api/v1/trade/order-tpsl"
+	EndpointCancelTPSL        = "/api/v1/trade/cancel-tpsl"
+	EndpointActiveTPSL        = "/api/v1/trade/orders-tpsl-pending"
+	EndpointTPSLHistory       = "/api/v1/trade/orders-tpsl-history"
+	EndpointPlaceAlgoOrder    = "/api/v1/trade/order-algo"
+	EndpointCancelAlgoOrder   = "/api/v1/trade/cancel-algo"
+	EndpointActiveAlgoOrders  = "/api/v1/trade/orders-algo-pending"
+	EndpointAlgoOrdersHistory = "/api/v1/trade/orders-algo-history"
+
+	// Account REST endpoints (private)
+	EndpointAccountBalance   = "/api/v1/account/balance"
+	EndpointPositions        = "/api/v1/account/positions"
+	EndpointPositionsHistory = "/api/v1/account/positions-history"
+	EndpointLeverageInfo     = "/api/v1/account/leverage-info"
+	EndpointSetLeverage      = "/api/v1/account/set-leverage"
+	EndpointMarginMode       = "/api/v1/account/margin-mode"
+	EndpointSetMarginMode    = "/api/v1/account/set-margin-mode"
+	EndpointPositionMode     = "/api/v1/account/position-mode"
+	EndpointSetPositionMode  = "/api/v1/account/set-position-mode"
+	EndpointAdjustMargin     = "/api/v1/account/adjust-position-margin"
+	EndpointBills            = "/api/v1/account/bills"
+
+	// Asset REST endpoints (private)
+	EndpointAssetBalances     = "/api/v1/asset/balances"
+	EndpointTransfer          = "/api/v1/asset/transfer"
+	EndpointTransferHistory   = "/api/v1/asset/bills"
+	EndpointDepositHistory    = "/api/v1/asset/deposit-history"
+	EndpointWithdrawalHistory = "/api/v1/asset/withdrawal-history"
+
 	// WebSocket URLs
-	WSURLProd = "wss://openapi.blofin.com/ws/public"
-	WSURLDemo = "wss://demo-trading-openapi.blofin.com/ws/public"
+	//
+	// Deprecated: use blofin.ProdPublicWSURL and blofin.DemoPublicWSURL.
+	WSURLProd = blofin.ProdPublicWSURL
+	WSURLDemo = blofin.DemoPublicWSURL
 
 	// API response codes
 	CodeSuccess = "0"
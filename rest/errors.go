@@ -0,0 +1,81 @@
+// Package rest provides RequestError, the error returned by failed REST requests.
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// maxBodySnippet is the maximum number of body bytes kept in RequestError.
+const maxBodySnippet = 512
+
+// RequestError describes a failed REST request: a transport error, an HTTP error status,
+// an undecodable response or an API error code. Err is the underlying error; for API errors
+// it is a *models.ApiError, so errors.As works as before.
+type RequestError struct {
+	Method     string
+	Endpoint   string        // request path without query
+	StatusCode int           // HTTP status, 0 if no response was received
+	Code       string        // API error code, empty if the body was not an API response
+	Message    string        // API error message
+	Body       string        // start of the response body
+	RetryAfter time.Duration // from the Retry-After header, 0 if absent
+	Err        error
+}
+
+func (e *RequestError) Error() string {
+	switch {
+	case e.Code != "":
+		return fmt.Sprintf("%s %s: code %s: %s", e.Method, e.Endpoint, e.Code, e.Message)
+	case e.StatusCode != 0 && !statusOK(e.StatusCode):
+		return fmt.Sprintf("%s %s: http %d %s: %q", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+	default:
+		return fmt.Sprintf("%s %s: %v", e.Method, e.Endpoint, e.Err)
+	}
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether e is an HTTP 429 response and target is blofin.ErrRateLimited.
+// API error codes are matched through Err.
+func (e *RequestError) Is(target error) bool {
+	return target == blofin.ErrRateLimited && e.RateLimited()
+}
+
+// RateLimited reports whether the server rejected the request with HTTP 429.
+func (e *RequestError) RateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// Temporary reports whether the failure is transient: HTTP 429, a 5xx status or a network timeout.
+// Such requests may succeed when retried.
+func (e *RequestError) Temporary() bool {
+	if e.RateLimited() || e.StatusCode >= 500 {
+		return true
+	}
+	var netErr net.Error
+	return e.StatusCode == 0 && errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// errHTTPStatus is the Err of requests failed with an HTTP error status and no API error code.
+var errHTTPStatus = errors.New("unexpected HTTP status")
+
+// statusOK reports whether status is 2xx.
+func statusOK(status int) bool {
+	return status >= 200 && status < 300
+}
+
+// bodySnippet returns the start of body.
+func bodySnippet(body []byte) string {
+	if len(body) > maxBodySnippet {
+		body = body[:maxBodySnippet]
+	}
+	return string(body)
+}
@@ -0,0 +1,194 @@
+// Package rest provides InstrumentRegistry, a cache of instrument trading rules refreshed from GetInstruments.
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+)
+
+// InstrumentRegistry holds the instruments returned by GetInstruments by instId, for rounding
+// prices and sizes, converting sizes and validating orders before they are sent (see models.Instrument).
+// It is safe for concurrent use.
+type InstrumentRegistry struct {
+	client *Client
+
+	mu          sync.RWMutex
+	instruments map[string]models.Instrument
+	updated     time.Time
+	onError     func(error)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewInstrumentRegistry creates an empty registry loading instruments with c.
+func NewInstrumentRegistry(c *Client) *InstrumentRegistry {
+	return &InstrumentRegistry{client: c, instruments: make(map[string]models.Instrument)}
+}
+
+// LoadInstruments creates a registry, loads the instruments and, if refresh is positive, reloads
+// them every refresh interval until Close.
+func LoadInstruments(ctx context.Context, c *Client, refresh time.Duration) (*InstrumentRegistry, error) {
+	r := NewInstrumentRegistry(c)
+	if err := r.Load(ctx); err != nil {
+		return nil, err
+	}
+	if refresh > 0 {
+		r.Start(refresh)
+	}
+	return r, nil
+}
+
+// Load replaces the instruments with those returned by GetInstruments.
+func (r *InstrumentRegistry) Load(ctx context.Context) error {
+	instruments, err := r.client.GetInstruments(ctx, nil)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]models.Instrument, len(instruments))
+	for _, inst := range instruments {
+		byID[inst.InstID] = inst
+	}
+	r.mu.Lock()
+	r.instruments = byID
+	r.updated = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// Start reloads the instruments every interval in the background until Close.
+// Failed reloads keep the previous instruments and are passed to the error handler.
+func (r *InstrumentRegistry) Start(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.mu.Unlock()
+		cancel()
+		return
+	}
+	r.cancel = cancel
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.Load(ctx); err != nil && ctx.Err() == nil {
+					r.reportError(err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the background reload.
+func (r *InstrumentRegistry) Close() {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.cancel = nil
+	r.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	r.wg.Wait()
+}
+
+// SetErrorHandler sets the callback for background reload errors.
+func (r *InstrumentRegistry) SetErrorHandler(handler func(error)) {
+	r.mu.Lock()
+	r.onError = handler
+	r.mu.Unlock()
+}
+
+// reportError passes err to the error handler if set.
+func (r *InstrumentRegistry) reportError(err error) {
+	r.mu.RLock()
+	onError := r.onError
+	r.mu.RUnlock()
+	if onError != nil {
+		onError(err)
+	}
+}
+
+// Get returns the instrument with instID.
+func (r *InstrumentRegistry) Get(instID string) (models.Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inst, ok := r.instruments[instID]
+	return inst, ok
+}
+
+// All returns all instruments in no particular order.
+func (r *InstrumentRegistry) All() []models.Instrument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	instruments := make([]models.Instrument, 0, len(r.instruments))
+	for _, inst := range r.instruments {
+		instruments = append(instruments, inst)
+	}
+	return instruments
+}
+
+// UpdatedAt returns the time of the last successful load.
+func (r *InstrumentRegistry) UpdatedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updated
+}
+
+// instrument returns the instrument with instID or an error matching blofin.ErrInstrumentNotFound.
+func (r *InstrumentRegistry) instrument(instID string) (models.Instrument, error) {
+	inst, ok := r.Get(instID)
+	if !ok {
+		return models.Instrument{}, fmt.Errorf("%w: %s", blofin.ErrInstrumentNotFound, instID)
+	}
+	return inst, nil
+}
+
+// RoundPrice rounds price to the tick size of instID.
+func (r *InstrumentRegistry) RoundPrice(instID string, price models.Decimal) (models.Decimal, error) {
+	inst, err := r.instrument(instID)
+	if err != nil {
+		return models.Decimal{}, err
+	}
+	return inst.RoundPrice(price), nil
+}
+
+// RoundSize rounds size (in contracts) toward zero to the lot size of instID.
+func (r *InstrumentRegistry) RoundSize(instID string, size models.Decimal) (models.Decimal, error) {
+	inst, err := r.instrument(instID)
+	if err != nil {
+		return models.Decimal{}, err
+	}
+	return inst.RoundSize(size), nil
+}
+
+// ValidateOrder checks req against the rules of its instrument (see models.Instrument.ValidateOrder).
+func (r *InstrumentRegistry) ValidateOrder(req models.PlaceOrderRequest) error {
+	inst, err := r.instrument(req.InstID)
+	if err != nil {
+		return err
+	}
+	size, err := models.ParseDecimal(req.Size)
+	if err != nil {
+		return fmt.Errorf("%w: size: %w", blofin.ErrInvalidParameter, err)
+	}
+	var price models.Decimal
+	if req.OrderType != models.OrderTypeMarket {
+		if price, err = models.ParseDecimal(req.Price); err != nil {
+			return fmt.Errorf("%w: price: %w", blofin.ErrInvalidParameter, err)
+		}
+	}
+	return inst.ValidateOrder(req.OrderType, price, size)
+}
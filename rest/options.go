@@ -0,0 +1,174 @@
+// Package rest provides the functional options of New.
+package rest
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// DefaultTimeout is the default timeout of a single HTTP request, including reading the response.
+const DefaultTimeout = 30 * time.Second
+
+// Option configures a Client created with New.
+type Option func(*options)
+
+// options holds the settings collected from Options.
+type options struct {
+	baseURL     string
+	env         blofin.Environment
+	creds       *Credentials
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     time.Duration
+	tlsConfig   *tls.Config
+	proxy       func(*http.Request) (*url.URL, error)
+	userAgent   string
+	header      http.Header
+	logger      *slog.Logger
+	limiter     *RateLimiter
+	retryPolicy *RetryPolicy
+}
+
+// WithBaseURL sets the API base URL (default BaseURLProd). The client Environment becomes the
+// known environment of baseURL, or the zero Environment for a custom URL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+		o.env, _ = blofin.EnvironmentOf(baseURL)
+	}
+}
+
+// WithEnvironment sets the base URL to env.RESTURL and records env as the client Environment
+// (see blofin.CheckEnvironments).
+func WithEnvironment(env blofin.Environment) Option {
+	return func(o *options) {
+		o.baseURL = env.RESTURL
+		o.env = env
+	}
+}
+
+// WithCredentials sets the API credentials required by private endpoints.
+func WithCredentials(apiKey, secretKey, passphrase string) Option {
+	return func(o *options) {
+		o.creds = &Credentials{APIKey: apiKey, SecretKey: secretKey, Passphrase: passphrase}
+	}
+}
+
+// WithEnvCredentials is like WithCredentials for an API key created for env: private requests are
+// refused with blofin.ErrEnvironmentMismatch unless the client belongs to env (see WithEnvironment).
+func WithEnvCredentials(env blofin.Environment, apiKey, secretKey, passphrase string) Option {
+	return func(o *options) {
+		o.creds = &Credentials{APIKey: apiKey, SecretKey: secretKey, Passphrase: passphrase, Environment: env}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used as is: WithTransport, WithTimeout, WithTLSConfig and
+// WithProxy are ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the HTTP transport: WithTLSConfig and WithProxy are ignored.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithTimeout sets the timeout of a single HTTP request (default DefaultTimeout, 0 = none).
+// Retries have their own timeout; use the request context to bound the whole call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the default transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithProxy sets the proxy of the default transport (default http.ProxyFromEnvironment).
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(o *options) {
+		o.proxy = proxy
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithLogger sets the logger of requests (debug) and retries (warn). By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRateLimiter sets the client-side rate limiter (default NewRateLimiter(DefaultRateLimits()), nil disables it).
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithRetryPolicy sets the retry policy of GET requests (default DefaultRetryPolicy(), nil disables retries).
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// defaultOptions returns the settings used when no Option overrides them.
+func defaultOptions() *options {
+	return &options{
+		baseURL:     BaseURLProd,
+		env:         blofin.Production,
+		timeout:     DefaultTimeout,
+		logger:      slog.New(slog.DiscardHandler),
+		limiter:     NewRateLimiter(DefaultRateLimits()),
+		retryPolicy: DefaultRetryPolicy(),
+	}
+}
+
+// client returns the HTTP client described by o.
+func (o *options) client() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	transport := o.transport
+	if transport == nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		if o.tlsConfig != nil {
+			t.TLSClientConfig = o.tlsConfig
+		}
+		if o.proxy != nil {
+			t.Proxy = o.proxy
+		}
+		transport = t
+	}
+	return &http.Client{Transport: transport, Timeout: o.timeout}
+}
@@ -0,0 +1,195 @@
+// Package rest provides iterators over paged REST endpoints.
+//
+// The iterators walk the records of an endpoint between two times page by page, with the after
+// cursor, and yield them one by one. They stop at the first error, which is yielded with a zero
+// record, and when ctx is done.
+package rest
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// PageOrder is the order in which an iterator yields records.
+type PageOrder int
+
+// Page orders
+const (
+	// Backward yields records from the newest to the oldest (default). Pages are fetched on demand.
+	Backward PageOrder = iota
+	// Forward yields records from the oldest to the newest. The endpoints only page backwards,
+	// so the whole range is fetched before the first record is yielded.
+	Forward
+)
+
+// PageRange selects the records of an iterator.
+type PageRange struct {
+	From     time.Time // oldest record time, inclusive; zero = no lower bound
+	To       time.Time // newest record time, exclusive; zero = now
+	Order    PageOrder
+	PageSize int // records per request; 0 or above the endpoint limit = the endpoint limit
+}
+
+// pager describes how to page an endpoint whose records are returned newest first.
+type pager[T any] struct {
+	endpoint string
+	// fetch returns the page of records older than cursor ("" = the newest page)
+	fetch  func(ctx context.Context, cursor string, limit int) ([]T, error)
+	time   func(T) time.Time
+	cursor func(T) string
+	// first is the cursor of the first page
+	first string
+}
+
+// pageSize returns the page size of r capped by the limit of endpoint.
+func pageSize(endpoint string, size int) int {
+	limit := paramRules[endpoint].maxOf("limit")
+	if size <= 0 || size > limit {
+		return limit
+	}
+	return size
+}
+
+// seq returns the iterator over the records of p in r.
+func (p pager[T]) seq(ctx context.Context, r PageRange) iter.Seq2[T, error] {
+	backward := p.backward(ctx, r)
+	if r.Order != Forward {
+		return backward
+	}
+	return func(yield func(T, error) bool) {
+		var records []T
+		for record, err := range backward {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			records = append(records, record)
+		}
+		for _, record := range slices.Backward(records) {
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// backward returns the iterator over the records of p in r from the newest to the oldest.
+func (p pager[T]) backward(ctx context.Context, r PageRange) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		limit := pageSize(p.endpoint, r.PageSize)
+		cursor := p.first
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := p.fetch(ctx, cursor, limit)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, record := range page {
+				t := p.time(record)
+				if !r.To.IsZero() && !t.Before(r.To) {
+					continue
+				}
+				if !r.From.IsZero() && t.Before(r.From) {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(record, nil) {
+					return
+				}
+			}
+			if len(page) < limit {
+				return
+			}
+			next := p.cursor(page[len(page)-1])
+			if next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// timeCursor returns the first cursor of time paged endpoints: records older than to.
+func timeCursor(to time.Time) string {
+	if to.IsZero() {
+		return ""
+	}
+	return models.FormatMillis(to)
+}
+
+// AllCandlesticks iterates over the candlesticks of p.InstID and p.Bar in r.
+// p.After, p.Before and p.Limit are overridden on a copy for each page.
+func (c *Client) AllCandlesticks(ctx context.Context, p CandlesticksParams, r PageRange) iter.Seq2[models.Candlestick, error] {
+	return pager[models.Candlestick]{
+		endpoint: EndpointCandlesticks,
+		fetch: func(ctx context.Context, cursor string, limit int) ([]models.Candlestick, error) {
+			q := p
+			q.After, q.Before, q.Limit = models.ParseMillis(cursor), time.Time{}, limit
+			return c.Candlesticks(ctx, q)
+		},
+		time:   models.Candlestick.Time,
+		cursor: func(k models.Candlestick) string { return k.Ts },
+		first:  timeCursor(r.To),
+	}.seq(ctx, r)
+}
+
+// AllFundingRateHistory iterates over the funding rates of p.InstID in r, by funding time.
+// p.After, p.Before and p.Limit are overridden on a copy for each page.
+func (c *Client) AllFundingRateHistory(ctx context.Context, p FundingRateHistoryParams, r PageRange) iter.Seq2[models.FundingRate, error] {
+	return pager[models.FundingRate]{
+		endpoint: EndpointFundingRateHist,
+		fetch: func(ctx context.Context, cursor string, limit int) ([]models.FundingRate, error) {
+			q := p
+			q.After, q.Before, q.Limit = models.ParseMillis(cursor), time.Time{}, limit
+			return c.FundingRateHistory(ctx, q)
+		},
+		time:   models.FundingRate.Time,
+		cursor: func(f models.FundingRate) string { return f.FundingTime },
+		first:  timeCursor(r.To),
+	}.seq(ctx, r)
+}
+
+// AllOrdersHistory iterates over the completed orders matching p in r, by creation time.
+// p.After, p.Before, p.Begin, p.End and p.Limit are overridden on a copy for each page.
+func (c *Client) AllOrdersHistory(ctx context.Context, p OrdersHistoryParams, r PageRange) iter.Seq2[models.Order, error] {
+	return pager[models.Order]{
+		endpoint: EndpointOrdersHistory,
+		fetch: func(ctx context.Context, cursor string, limit int) ([]models.Order, error) {
+			q := p
+			q.Begin, q.End = r.From, r.To
+			q.After, q.Before, q.Limit = cursor, "", limit
+			return c.OrdersHistory(ctx, q)
+		},
+		time:   func(o models.Order) time.Time { return models.ParseMillis(o.CreateTime) },
+		cursor: func(o models.Order) string { return o.OrderID },
+	}.seq(ctx, r)
+}
+
+// AllFillsHistory iterates over the fills matching p in r.
+// p.After, p.Before, p.Begin, p.End and p.Limit are overridden on a copy for each page.
+func (c *Client) AllFillsHistory(ctx context.Context, p FillsHistoryParams, r PageRange) iter.Seq2[models.Fill, error] {
+	return pager[models.Fill]{
+		endpoint: EndpointFillsHistory,
+		fetch: func(ctx context.Context, cursor string, limit int) ([]models.Fill, error) {
+			q := p
+			q.Begin, q.End = r.From, r.To
+			q.After, q.Before, q.Limit = cursor, "", limit
+			return c.FillsHistory(ctx, q)
+		},
+		time:   models.Fill.Time,
+		cursor: func(f models.Fill) string { return f.TradeID },
+	}.seq(ctx, r)
+}
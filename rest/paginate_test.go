@@ -0,0 +1,238 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testPagerBase is the time of record 0 of testPager; record i is i minutes later.
+var testPagerBase = time.UnixMilli(1700000000000)
+
+// testPager returns a pager over records 0..n-1 (newest first) that records the requested cursors.
+// Record i has cursor strconv.Itoa(i); a page holds the records below the cursor.
+func testPager(n int, cursors *[]string) pager[int] {
+	return pager[int]{
+		endpoint: EndpointCandlesticks,
+		fetch: func(ctx context.Context, cursor string, limit int) ([]int, error) {
+			*cursors = append(*cursors, cursor)
+			below := n
+			if cursor != "" {
+				below, _ = strconv.Atoi(cursor)
+			}
+			var page []int
+			for i := below - 1; i >= 0 && len(page) < limit; i-- {
+				page = append(page, i)
+			}
+			return page, nil
+		},
+		time:   minute,
+		cursor: strconv.Itoa,
+	}
+}
+
+// minute returns the time of record i of testPager.
+func minute(i int) time.Time {
+	return testPagerBase.Add(time.Duration(i) * time.Minute)
+}
+
+func TestPagerCursors(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     int
+		r           PageRange
+		want        []int
+		wantCursors []string
+	}{
+		{
+			name:        "short last page stops",
+			records:     5,
+			r:           PageRange{PageSize: 2},
+			want:        []int{4, 3, 2, 1, 0},
+			wantCursors: []string{"", "3", "1"},
+		},
+		{
+			name:        "empty last page stops",
+			records:     4,
+			r:           PageRange{PageSize: 2},
+			want:        []int{3, 2, 1, 0},
+			wantCursors: []string{"", "2", "0"},
+		},
+		{
+			name:        "single page",
+			records:     3,
+			r:           PageRange{PageSize: 10},
+			want:        []int{2, 1, 0},
+			wantCursors: []string{""},
+		},
+		{
+			name:        "no records",
+			records:     0,
+			r:           PageRange{PageSize: 10},
+			want:        nil,
+			wantCursors: []string{""},
+		},
+		{
+			name:        "From stops before the next page",
+			records:     10,
+			r:           PageRange{From: minute(6), PageSize: 3},
+			want:        []int{9, 8, 7, 6},
+			wantCursors: []string{"", "7"},
+		},
+		{
+			name:        "To is exclusive",
+			records:     10,
+			r:           PageRange{From: minute(3), To: minute(6), PageSize: 4},
+			want:        []int{5, 4, 3},
+			wantCursors: []string{"", "6"},
+		},
+		{
+			name:        "page size above the endpoint limit is capped",
+			records:     3,
+			r:           PageRange{PageSize: 1 << 20},
+			want:        []int{2, 1, 0},
+			wantCursors: []string{""},
+		},
+		{
+			name:        "Forward reverses the range",
+			records:     5,
+			r:           PageRange{From: minute(1), To: minute(4), Order: Forward, PageSize: 2},
+			want:        []int{1, 2, 3},
+			wantCursors: []string{"", "3", "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursors []string
+			var got []int
+			for record, err := range testPager(tt.records, &cursors).seq(context.Background(), tt.r) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, record)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(cursors, tt.wantCursors) {
+				t.Errorf("cursors = %q, want %q", cursors, tt.wantCursors)
+			}
+		})
+	}
+}
+
+func TestPagerStops(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	tests := []struct {
+		name      string
+		pager     func(p pager[int]) pager[int]
+		order     PageOrder
+		stopAfter int // records consumed before breaking; 0 = all
+		want      []int
+		wantErr   error
+	}{
+		{
+			name: "fetch error is yielded",
+			pager: func(p pager[int]) pager[int] {
+				fetch := p.fetch
+				p.fetch = func(ctx context.Context, cursor string, limit int) ([]int, error) {
+					if cursor != "" {
+						return nil, errFetch
+					}
+					return fetch(ctx, cursor, limit)
+				}
+				return p
+			},
+			want:    []int{9, 8},
+			wantErr: errFetch,
+		},
+		{
+			name: "fetch error yields nothing in Forward order",
+			pager: func(p pager[int]) pager[int] {
+				p.fetch = func(ctx context.Context, cursor string, limit int) ([]int, error) {
+					if cursor != "" {
+						return nil, errFetch
+					}
+					return []int{9, 8}, nil
+				}
+				return p
+			},
+			order:   Forward,
+			wantErr: errFetch,
+		},
+		{
+			name: "repeated cursor stops",
+			pager: func(p pager[int]) pager[int] {
+				p.first = "9"
+				p.cursor = func(int) string { return "9" }
+				return p
+			},
+			want: []int{8, 7},
+		},
+		{
+			name: "empty cursor stops",
+			pager: func(p pager[int]) pager[int] {
+				p.cursor = func(int) string { return "" }
+				return p
+			},
+			want: []int{9, 8},
+		},
+		{
+			name:      "consumer break stops",
+			pager:     func(p pager[int]) pager[int] { return p },
+			stopAfter: 3,
+			want:      []int{9, 8, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursors []string
+			p := tt.pager(testPager(10, &cursors))
+			var got []int
+			var gotErr error
+			for record, err := range p.seq(context.Background(), PageRange{Order: tt.order, PageSize: 2}) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, record)
+				if len(got) == tt.stopAfter {
+					break
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("err = %v, want %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPagerContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var cursors []string
+	var got []int
+	var gotErr error
+	for record, err := range testPager(10, &cursors).seq(ctx, PageRange{PageSize: 4}) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, record)
+		if len(got) == 2 {
+			cancel()
+		}
+	}
+	if !slices.Equal(got, []int{9, 8}) || !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("records = %v, err = %v; want [9 8], context.Canceled", got, gotErr)
+	}
+	if len(cursors) != 1 {
+		t.Errorf("fetched %d pages after cancellation, want 1", len(cursors))
+	}
+}
@@ -0,0 +1,610 @@
+// Package rest provides typed query params for REST endpoints.
+//
+// Every GET method taking url.Values has a typed variant without the Get prefix that takes a params
+// struct (e.g. Candlesticks with CandlesticksParams). Zero fields are omitted from the query, times
+// are sent as milliseconds. Both variants share the same validation (see validate.go).
+package rest
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// query builds url.Values skipping zero values.
+type query url.Values
+
+func (q query) setString(key, v string) {
+	if v != "" {
+		url.Values(q).Set(key, v)
+	}
+}
+
+func (q query) setInt(key string, v int) {
+	if v != 0 {
+		url.Values(q).Set(key, strconv.Itoa(v))
+	}
+}
+
+func (q query) setTime(key string, v time.Time) {
+	if !v.IsZero() {
+		url.Values(q).Set(key, models.FormatMillis(v))
+	}
+}
+
+// InstrumentsParams are the query params of GetInstruments.
+type InstrumentsParams struct {
+	InstID string
+}
+
+// Values returns p as query params.
+func (p InstrumentsParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	return url.Values(q)
+}
+
+// Instruments fetches the list of trading instruments. It is the typed variant of GetInstruments.
+func (c *Client) Instruments(ctx context.Context, p InstrumentsParams) ([]models.Instrument, error) {
+	return c.GetInstruments(ctx, p.Values())
+}
+
+// CandlesticksParams are the query params of GetCandlesticks.
+type CandlesticksParams struct {
+	InstID string    // required
+	Bar    string    // models.Bar* constant, default 1m
+	After  time.Time // candles before this time (older)
+	Before time.Time // candles after this time (newer)
+	Limit  int       // max 1440
+}
+
+// Values returns p as query params.
+func (p CandlesticksParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("bar", p.Bar)
+	q.setTime("after", p.After)
+	q.setTime("before", p.Before)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// Candlesticks fetches candlesticks of an instrument. It is the typed variant of GetCandlesticks.
+func (c *Client) Candlesticks(ctx context.Context, p CandlesticksParams) ([]models.Candlestick, error) {
+	return c.GetCandlesticks(ctx, p.Values())
+}
+
+// TickersParams are the query params of GetTickers.
+type TickersParams struct {
+	InstID string
+}
+
+// Values returns p as query params.
+func (p TickersParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	return url.Values(q)
+}
+
+// Tickers fetches ticker snapshots. It is the typed variant of GetTickers.
+func (c *Client) Tickers(ctx context.Context, p TickersParams) ([]models.Ticker, error) {
+	return c.GetTickers(ctx, p.Values())
+}
+
+// OrderBookParams are the query params of GetOrderBook.
+type OrderBookParams struct {
+	InstID string // required
+	Size   int    // depth, max 100
+}
+
+// Values returns p as query params.
+func (p OrderBookParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setInt("size", p.Size)
+	return url.Values(q)
+}
+
+// OrderBook fetches the order book of an instrument. It is the typed variant of GetOrderBook.
+func (c *Client) OrderBook(ctx context.Context, p OrderBookParams) (*models.OrderBook, error) {
+	return c.GetOrderBook(ctx, p.Values())
+}
+
+// TradesParams are the query params of GetTrades.
+type TradesParams struct {
+	InstID string // required
+	Limit  int    // max 100
+}
+
+// Values returns p as query params.
+func (p TradesParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// Trades fetches recent trades of an instrument. It is the typed variant of GetTrades.
+func (c *Client) Trades(ctx context.Context, p TradesParams) ([]models.Trade, error) {
+	return c.GetTrades(ctx, p.Values())
+}
+
+// MarkPriceParams are the query params of GetMarkPrice.
+type MarkPriceParams struct {
+	InstID string
+}
+
+// Values returns p as query params.
+func (p MarkPriceParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	return url.Values(q)
+}
+
+// MarkPrice fetches index and mark prices. It is the typed variant of GetMarkPrice.
+func (c *Client) MarkPrice(ctx context.Context, p MarkPriceParams) ([]models.MarkPrice, error) {
+	return c.GetMarkPrice(ctx, p.Values())
+}
+
+// FundingRateParams are the query params of GetFundingRate.
+type FundingRateParams struct {
+	InstID string
+}
+
+// Values returns p as query params.
+func (p FundingRateParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	return url.Values(q)
+}
+
+// FundingRate fetches current funding rates. It is the typed variant of GetFundingRate.
+func (c *Client) FundingRate(ctx context.Context, p FundingRateParams) ([]models.FundingRate, error) {
+	return c.GetFundingRate(ctx, p.Values())
+}
+
+// FundingRateHistoryParams are the query params of GetFundingRateHistory.
+type FundingRateHistoryParams struct {
+	InstID string    // required
+	Before time.Time // records newer than this funding time
+	After  time.Time // records older than this funding time
+	Limit  int       // max 100
+}
+
+// Values returns p as query params.
+func (p FundingRateHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setTime("before", p.Before)
+	q.setTime("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// FundingRateHistory fetches funding rate history of an instrument. It is the typed variant of GetFundingRateHistory.
+func (c *Client) FundingRateHistory(ctx context.Context, p FundingRateHistoryParams) ([]models.FundingRate, error) {
+	return c.GetFundingRateHistory(ctx, p.Values())
+}
+
+// ActiveOrdersParams are the query params of GetActiveOrders.
+type ActiveOrdersParams struct {
+	InstID    string
+	OrderType string
+	State     string
+	Before    string // orderId cursor
+	After     string // orderId cursor
+	Limit     int    // max 100
+}
+
+// Values returns p as query params.
+func (p ActiveOrdersParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("orderType", p.OrderType)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// ActiveOrders fetches incomplete orders. It is the typed variant of GetActiveOrders.
+func (c *Client) ActiveOrders(ctx context.Context, p ActiveOrdersParams) ([]models.Order, error) {
+	return c.GetActiveOrders(ctx, p.Values())
+}
+
+// OrderDetailParams are the query params of GetOrderDetail.
+type OrderDetailParams struct {
+	InstID        string // required
+	OrderID       string // OrderID or ClientOrderID is required
+	ClientOrderID string
+}
+
+// Values returns p as query params.
+func (p OrderDetailParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("orderId", p.OrderID)
+	q.setString("clientOrderId", p.ClientOrderID)
+	return url.Values(q)
+}
+
+// OrderDetail fetches a single order. It is the typed variant of GetOrderDetail.
+func (c *Client) OrderDetail(ctx context.Context, p OrderDetailParams) (*models.Order, error) {
+	return c.GetOrderDetail(ctx, p.Values())
+}
+
+// OrdersHistoryParams are the query params of GetOrdersHistory.
+type OrdersHistoryParams struct {
+	InstID    string
+	OrderType string
+	State     string
+	Before    string // orderId cursor
+	After     string // orderId cursor
+	Begin     time.Time
+	End       time.Time
+	Limit     int // max 100
+}
+
+// Values returns p as query params.
+func (p OrdersHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("orderType", p.OrderType)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setTime("begin", p.Begin)
+	q.setTime("end", p.End)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// OrdersHistory fetches completed orders. It is the typed variant of GetOrdersHistory.
+func (c *Client) OrdersHistory(ctx context.Context, p OrdersHistoryParams) ([]models.Order, error) {
+	return c.GetOrdersHistory(ctx, p.Values())
+}
+
+// FillsHistoryParams are the query params of GetFillsHistory.
+type FillsHistoryParams struct {
+	InstID  string
+	OrderID string
+	Before  string // tradeId cursor
+	After   string // tradeId cursor
+	Begin   time.Time
+	End     time.Time
+	Limit   int // max 100
+}
+
+// Values returns p as query params.
+func (p FillsHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("orderId", p.OrderID)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setTime("begin", p.Begin)
+	q.setTime("end", p.End)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// FillsHistory fetches trade fills. It is the typed variant of GetFillsHistory.
+func (c *Client) FillsHistory(ctx context.Context, p FillsHistoryParams) ([]models.Fill, error) {
+	return c.GetFillsHistory(ctx, p.Values())
+}
+
+// ActiveTPSLParams are the query params of GetActiveTPSL.
+type ActiveTPSLParams struct {
+	InstID        string
+	TpslID        string
+	ClientOrderID string
+	Before        string
+	After         string
+	Limit         int // max 100
+}
+
+// Values returns p as query params.
+func (p ActiveTPSLParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("tpslId", p.TpslID)
+	q.setString("clientOrderId", p.ClientOrderID)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// ActiveTPSL fetches pending TP/SL orders. It is the typed variant of GetActiveTPSL.
+func (c *Client) ActiveTPSL(ctx context.Context, p ActiveTPSLParams) ([]models.TPSLOrder, error) {
+	return c.GetActiveTPSL(ctx, p.Values())
+}
+
+// TPSLHistoryParams are the query params of GetTPSLHistory.
+type TPSLHistoryParams struct {
+	InstID        string
+	TpslID        string
+	ClientOrderID string
+	State         string
+	Before        string
+	After         string
+	Limit         int // max 100
+}
+
+// Values returns p as query params.
+func (p TPSLHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("tpslId", p.TpslID)
+	q.setString("clientOrderId", p.ClientOrderID)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// TPSLHistory fetches completed TP/SL orders. It is the typed variant of GetTPSLHistory.
+func (c *Client) TPSLHistory(ctx context.Context, p TPSLHistoryParams) ([]models.TPSLOrder, error) {
+	return c.GetTPSLHistory(ctx, p.Values())
+}
+
+// ActiveAlgoOrdersParams are the query params of GetActiveAlgoOrders.
+type ActiveAlgoOrdersParams struct {
+	OrderType     string // required, models.AlgoOrderTypeTrigger
+	InstID        string
+	AlgoID        string
+	ClientOrderID string
+	Before        string
+	After         string
+	Limit         int // max 100
+}
+
+// Values returns p as query params.
+func (p ActiveAlgoOrdersParams) Values() url.Values {
+	q := query{}
+	q.setString("orderType", p.OrderType)
+	q.setString("instId", p.InstID)
+	q.setString("algoId", p.AlgoID)
+	q.setString("clientOrderId", p.ClientOrderID)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// ActiveAlgoOrders fetches pending trigger orders. It is the typed variant of GetActiveAlgoOrders.
+func (c *Client) ActiveAlgoOrders(ctx context.Context, p ActiveAlgoOrdersParams) ([]models.AlgoOrder, error) {
+	return c.GetActiveAlgoOrders(ctx, p.Values())
+}
+
+// AlgoOrdersHistoryParams are the query params of GetAlgoOrdersHistory.
+type AlgoOrdersHistoryParams struct {
+	OrderType     string // required, models.AlgoOrderTypeTrigger
+	InstID        string
+	AlgoID        string
+	ClientOrderID string
+	State         string
+	Before        string
+	After         string
+	Limit         int // max 100
+}
+
+// Values returns p as query params.
+func (p AlgoOrdersHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("orderType", p.OrderType)
+	q.setString("instId", p.InstID)
+	q.setString("algoId", p.AlgoID)
+	q.setString("clientOrderId", p.ClientOrderID)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// AlgoOrdersHistory fetches completed trigger orders. It is the typed variant of GetAlgoOrdersHistory.
+func (c *Client) AlgoOrdersHistory(ctx context.Context, p AlgoOrdersHistoryParams) ([]models.AlgoOrder, error) {
+	return c.GetAlgoOrdersHistory(ctx, p.Values())
+}
+
+// PositionsParams are the query params of GetPositions.
+type PositionsParams struct {
+	InstID string
+}
+
+// Values returns p as query params.
+func (p PositionsParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	return url.Values(q)
+}
+
+// Positions fetches open positions. It is the typed variant of GetPositions.
+func (c *Client) Positions(ctx context.Context, p PositionsParams) ([]models.Position, error) {
+	return c.GetPositions(ctx, p.Values())
+}
+
+// PositionsHistoryParams are the query params of GetPositionsHistory.
+type PositionsHistoryParams struct {
+	InstID     string
+	PositionID string
+	Before     string
+	After      string
+	Limit      int // max 100
+}
+
+// Values returns p as query params.
+func (p PositionsHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("positionId", p.PositionID)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// PositionsHistory fetches closed positions. It is the typed variant of GetPositionsHistory.
+func (c *Client) PositionsHistory(ctx context.Context, p PositionsHistoryParams) ([]models.PositionHistory, error) {
+	return c.GetPositionsHistory(ctx, p.Values())
+}
+
+// LeverageInfoParams are the query params of GetLeverageInfo.
+type LeverageInfoParams struct {
+	InstID     string // required
+	MarginMode string // required
+}
+
+// Values returns p as query params.
+func (p LeverageInfoParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("marginMode", p.MarginMode)
+	return url.Values(q)
+}
+
+// LeverageInfo fetches leverage of an instrument. It is the typed variant of GetLeverageInfo.
+func (c *Client) LeverageInfo(ctx context.Context, p LeverageInfoParams) (*models.LeverageInfo, error) {
+	return c.GetLeverageInfo(ctx, p.Values())
+}
+
+// BillsParams are the query params of GetBills.
+type BillsParams struct {
+	InstID   string
+	Currency string
+	Type     string // models.BillType* constant
+	Before   string // billId cursor
+	After    string // billId cursor
+	Begin    time.Time
+	End      time.Time
+	Limit    int // max 100
+}
+
+// Values returns p as query params.
+func (p BillsParams) Values() url.Values {
+	q := query{}
+	q.setString("instId", p.InstID)
+	q.setString("currency", p.Currency)
+	q.setString("type", p.Type)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setTime("begin", p.Begin)
+	q.setTime("end", p.End)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// Bills fetches futures account ledger entries. It is the typed variant of GetBills.
+func (c *Client) Bills(ctx context.Context, p BillsParams) ([]models.Bill, error) {
+	return c.GetBills(ctx, p.Values())
+}
+
+// AssetBalancesParams are the query params of GetAssetBalances.
+type AssetBalancesParams struct {
+	AccountType string // required, models.AccountType* constant
+	Currency    string
+}
+
+// Values returns p as query params.
+func (p AssetBalancesParams) Values() url.Values {
+	q := query{}
+	q.setString("accountType", p.AccountType)
+	q.setString("currency", p.Currency)
+	return url.Values(q)
+}
+
+// AssetBalances fetches balances of an asset account. It is the typed variant of GetAssetBalances.
+func (c *Client) AssetBalances(ctx context.Context, p AssetBalancesParams) ([]models.AssetBalance, error) {
+	return c.GetAssetBalances(ctx, p.Values())
+}
+
+// TransferHistoryParams are the query params of GetTransferHistory.
+type TransferHistoryParams struct {
+	Currency    string
+	FromAccount string
+	ToAccount   string
+	Before      string // transferId cursor
+	After       string // transferId cursor
+	Limit       int    // max 100
+}
+
+// Values returns p as query params.
+func (p TransferHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("currency", p.Currency)
+	q.setString("fromAccount", p.FromAccount)
+	q.setString("toAccount", p.ToAccount)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// TransferHistory fetches funds transfer history. It is the typed variant of GetTransferHistory.
+func (c *Client) TransferHistory(ctx context.Context, p TransferHistoryParams) ([]models.Transfer, error) {
+	return c.GetTransferHistory(ctx, p.Values())
+}
+
+// DepositHistoryParams are the query params of GetDepositHistory.
+type DepositHistoryParams struct {
+	Currency  string
+	DepositID string
+	TxID      string
+	State     string
+	Before    string // depositId cursor
+	After     string // depositId cursor
+	Limit     int    // max 100
+}
+
+// Values returns p as query params.
+func (p DepositHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("currency", p.Currency)
+	q.setString("depositId", p.DepositID)
+	q.setString("txId", p.TxID)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// DepositHistory fetches deposit history. It is the typed variant of GetDepositHistory.
+func (c *Client) DepositHistory(ctx context.Context, p DepositHistoryParams) ([]models.Deposit, error) {
+	return c.GetDepositHistory(ctx, p.Values())
+}
+
+// WithdrawalHistoryParams are the query params of GetWithdrawalHistory.
+type WithdrawalHistoryParams struct {
+	Currency   string
+	WithdrawID string
+	TxID       string
+	State      string
+	Before     string // withdrawId cursor
+	After      string // withdrawId cursor
+	Limit      int    // max 100
+}
+
+// Values returns p as query params.
+func (p WithdrawalHistoryParams) Values() url.Values {
+	q := query{}
+	q.setString("currency", p.Currency)
+	q.setString("withdrawId", p.WithdrawID)
+	q.setString("txId", p.TxID)
+	q.setString("state", p.State)
+	q.setString("before", p.Before)
+	q.setString("after", p.After)
+	q.setInt("limit", p.Limit)
+	return url.Values(q)
+}
+
+// WithdrawalHistory fetches withdrawal history. It is the typed variant of GetWithdrawalHistory.
+func (c *Client) WithdrawalHistory(ctx context.Context, p WithdrawalHistoryParams) ([]models.Withdrawal, error) {
+	return c.GetWithdrawalHistory(ctx, p.Values())
+}
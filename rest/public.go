@@ -3,9 +3,7 @@ package rest
 
 import (
 	"context"
-	"errors"
 	"net/url"
-	"strconv"
 
 	"github.com/mmavka/go-blofin/models"
 )
@@ -15,6 +13,9 @@ import (
 // Returns a slice of Instrument or an error (including rate limit and API errors).
 // Params: instId (optional)
 func (c *Client) GetInstruments(ctx context.Context, params url.Values) ([]models.Instrument, error) {
+	if err := validateParams(EndpointInstruments, params); err != nil {
+		return nil, err
+	}
 	var instruments []models.Instrument
 	err := c.doGet(ctx, EndpointInstruments, params, &instruments)
 	if err != nil {
@@ -25,19 +26,10 @@ func (c *Client) GetInstruments(ctx context.Context, params url.Values) ([]model
 
 // GetCandlesticks fetches candlestick data for a given instrument.
 // Params: instId (required), bar, after, before, limit (see API docs)
+// Malformed rows are skipped and logged at debug level (see WithLogger).
 func (c *Client) GetCandlesticks(ctx context.Context, params url.Values) ([]models.Candlestick, error) {
-	// check required params
-	if params.Get("instId") == "" {
-		return nil, errors.New("instId is required")
-	}
-	if params.Get("limit") != "" {
-		limit, err := strconv.Atoi(params.Get("limit"))
-		if err != nil {
-			return nil, errors.New("limit must be a number")
-		}
-		if limit > 1440 {
-			return nil, errors.New("limit must be less than 1440")
-		}
+	if err := validateParams(EndpointCandlesticks, params); err != nil {
+		return nil, err
 	}
 	var rawData [][]string
 	err := c.doGet(ctx, EndpointCandlesticks, params, &rawData)
@@ -47,20 +39,12 @@ func (c *Client) GetCandlesticks(ctx context.Context, params url.Values) ([]mode
 
 	candles := make([]models.Candlestick, 0, len(rawData))
 	for _, arr := range rawData {
-		if len(arr) < 9 {
-			continue // skip malformed
+		candle, ok := models.ParseCandlestick(arr)
+		if !ok {
+			c.logger.DebugContext(ctx, "skipping malformed candlestick", "instId", params.Get("instId"), "row", arr)
+			continue
 		}
-		candles = append(candles, models.Candlestick{
-			Ts:             arr[0],
-			Open:           arr[1],
-			High:           arr[2],
-			Low:            arr[3],
-			Close:          arr[4],
-			Volume:         arr[5],
-			VolumeCurrency: arr[6],
-			VolumeQuote:    arr[7],
-			Confirm:        arr[8],
-		})
+		candles = append(candles, candle)
 	}
 	return candles, nil
 }
@@ -68,6 +52,9 @@ func (c *Client) GetCandlesticks(ctx context.Context, params url.Values) ([]mode
 // GetTickers fetches the latest price snapshot, best bid/ask, and 24h volume.
 // Params: instId (optional)
 func (c *Client) GetTickers(ctx context.Context, params url.Values) ([]models.Ticker, error) {
+	if err := validateParams(EndpointTickers, params); err != nil {
+		return nil, err
+	}
 	var tickers []models.Ticker
 	err := c.doGet(ctx, EndpointTickers, params, &tickers)
 	if err != nil {
@@ -78,19 +65,10 @@ func (c *Client) GetTickers(ctx context.Context, params url.Values) ([]models.Ti
 
 // GetOrderBook fetches the order book for a given instrument.
 // Params: instId (required), size (optional)
+// Malformed levels are skipped and logged at debug level (see WithLogger).
 func (c *Client) GetOrderBook(ctx context.Context, params url.Values) (*models.OrderBook, error) {
-	// check required params
-	if params.Get("instId") == "" {
-		return nil, errors.New("instId is required")
-	}
-	if params.Get("size") != "" {
-		size, err := strconv.Atoi(params.Get("size"))
-		if err != nil {
-			return nil, errors.New("size must be a number")
-		}
-		if size > 100 {
-			return nil, errors.New("size must be less than 100")
-		}
+	if err := validateParams(EndpointOrderBook, params); err != nil {
+		return nil, err
 	}
 	var rawBooks []struct {
 		Asks [][]string `json:"asks"`
@@ -110,16 +88,20 @@ func (c *Client) GetOrderBook(ctx context.Context, params url.Values) (*models.O
 		Ts:   rawBooks[0].Ts,
 	}
 	for _, arr := range rawBooks[0].Asks {
-		if len(arr) < 2 {
+		level, ok := models.ParseOrderBookLevel(arr)
+		if !ok {
+			c.logger.DebugContext(ctx, "skipping malformed order book level", "instId", params.Get("instId"), "level", arr)
 			continue
 		}
-		ob.Asks = append(ob.Asks, models.OrderBookLevel{Price: arr[0], Quantity: arr[1]})
+		ob.Asks = append(ob.Asks, level)
 	}
 	for _, arr := range rawBooks[0].Bids {
-		if len(arr) < 2 {
+		level, ok := models.ParseOrderBookLevel(arr)
+		if !ok {
+			c.logger.DebugContext(ctx, "skipping malformed order book level", "instId", params.Get("instId"), "level", arr)
 			continue
 		}
-		ob.Bids = append(ob.Bids, models.OrderBookLevel{Price: arr[0], Quantity: arr[1]})
+		ob.Bids = append(ob.Bids, level)
 	}
 	return ob, nil
 }
@@ -127,18 +109,8 @@ func (c *Client) GetOrderBook(ctx context.Context, params url.Values) (*models.O
 // GetTrades fetches recent transactions for a given instrument.
 // Params: instId (required), limit (optional)
 func (c *Client) GetTrades(ctx context.Context, params url.Values) ([]models.Trade, error) {
-	// check required params
-	if params.Get("instId") == "" {
-		return nil, errors.New("instId is required")
-	}
-	if params.Get("limit") != "" {
-		limit, err := strconv.Atoi(params.Get("limit"))
-		if err != nil {
-			return nil, errors.New("limit must be a number")
-		}
-		if limit > 100 {
-			return nil, errors.New("limit must be less than 100")
-		}
+	if err := validateParams(EndpointTrades, params); err != nil {
+		return nil, err
 	}
 	var trades []models.Trade
 	err := c.doGet(ctx, EndpointTrades, params, &trades)
@@ -151,6 +123,9 @@ func (c *Client) GetTrades(ctx context.Context, params url.Values) ([]models.Tra
 // GetMarkPrice fetches index and mark price for an instrument.
 // Params: instId (optional)
 func (c *Client) GetMarkPrice(ctx context.Context, params url.Values) ([]models.MarkPrice, error) {
+	if err := validateParams(EndpointMarkPrice, params); err != nil {
+		return nil, err
+	}
 	var prices []models.MarkPrice
 	err := c.doGet(ctx, EndpointMarkPrice, params, &prices)
 	if err != nil {
@@ -162,6 +137,9 @@ func (c *Client) GetMarkPrice(ctx context.Context, params url.Values) ([]models.
 // GetFundingRate fetches funding rate for an instrument.
 // Params: instId (optional)
 func (c *Client) GetFundingRate(ctx context.Context, params url.Values) ([]models.FundingRate, error) {
+	if err := validateParams(EndpointFundingRate, params); err != nil {
+		return nil, err
+	}
 	var rates []models.FundingRate
 	err := c.doGet(ctx, EndpointFundingRate, params, &rates)
 	if err != nil {
@@ -173,18 +151,8 @@ func (c *Client) GetFundingRate(ctx context.Context, params url.Values) ([]model
 // GetFundingRateHistory fetches funding rate history for an instrument.
 // Params: instId (required), before, after, limit (optional)
 func (c *Client) GetFundingRateHistory(ctx context.Context, params url.Values) ([]models.FundingRate, error) {
-	// check required params
-	if params.Get("instId") == "" {
-		return nil, errors.New("instId is required")
-	}
-	if params.Get("limit") != "" {
-		limit, err := strconv.Atoi(params.Get("limit"))
-		if err != nil {
-			return nil, errors.New("limit must be a number")
-		}
-		if limit > 100 {
-			return nil, errors.New("limit must be less than 100")
-		}
+	if err := validateParams(EndpointFundingRateHist, params); err != nil {
+		return nil, err
 	}
 	var rates []models.FundingRate
 	err := c.doGet(ctx, EndpointFundingRateHist, params, &rates)
@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestGetCandlesticksSkipsMalformedRows(t *testing.T) {
+	c := New(WithBaseURL(newTestServer(t, `{"code":"0","msg":"","data":[
+		["1700000060000","101","102","100","101.5","10","1015","1015","1"],
+		["1700000000000","oops","102","100","101.5","10","1015","1015","1"],
+		["1699999940000"]
+	]}`)), WithRetryPolicy(nil))
+	candles, err := c.GetCandlesticks(context.Background(), url.Values{"instId": {"BTC-USDT"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 || candles[0].Ts != "1700000060000" {
+		t.Fatalf("candles = %+v, want the first row only", candles)
+	}
+}
+
+func TestGetOrderBookSkipsMalformedLevels(t *testing.T) {
+	c := New(WithBaseURL(newTestServer(t, `{"code":"0","msg":"","data":[{
+		"asks":[["101","1"],["x","2"]],
+		"bids":[["100"],["99","3"]],
+		"ts":"1700000000000"
+	}]}`)), WithRetryPolicy(nil))
+	ob, err := c.GetOrderBook(context.Background(), url.Values{"instId": {"BTC-USDT"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Asks) != 1 || ob.Asks[0].Price.String() != "101" {
+		t.Errorf("asks = %+v, want [101]", ob.Asks)
+	}
+	if len(ob.Bids) != 1 || ob.Bids[0].Price.String() != "99" {
+		t.Errorf("bids = %+v, want [99]", ob.Bids)
+	}
+}
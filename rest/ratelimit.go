@@ -0,0 +1,194 @@
+// Package rest provides a client-side token-bucket rate limiter keyed by Blofin endpoint group.
+package rest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup is a group of endpoints sharing a rate limit budget.
+type EndpointGroup string
+
+// Endpoint groups
+const (
+	GroupPublic  EndpointGroup = "public"  // market data (/api/v1/market)
+	GroupTrading EndpointGroup = "trading" // orders, TP/SL and algo orders (/api/v1/trade)
+	GroupAccount EndpointGroup = "account" // balance, positions, leverage, bills (/api/v1/account)
+	GroupAsset   EndpointGroup = "asset"   // asset balances, transfers, deposits, withdrawals (/api/v1/asset)
+)
+
+// RateLimit is a budget of Requests per Interval. Up to Requests may be sent in a burst.
+type RateLimit struct {
+	Requests int
+	Interval time.Duration
+}
+
+// DefaultRateLimits returns the documented Blofin budgets: 500 requests per minute for public market data
+// and 30 requests per 10 seconds for trading, account and asset endpoints.
+func DefaultRateLimits() map[EndpointGroup]RateLimit {
+	return map[EndpointGroup]RateLimit{
+		GroupPublic:  {Requests: 500, Interval: time.Minute},
+		GroupTrading: {Requests: 30, Interval: 10 * time.Second},
+		GroupAccount: {Requests: 30, Interval: 10 * time.Second},
+		GroupAsset:   {Requests: 30, Interval: 10 * time.Second},
+	}
+}
+
+// RateLimitUsage describes the current state of a group budget.
+type RateLimitUsage struct {
+	Group     EndpointGroup
+	Limit     RateLimit
+	Available int // requests that can be sent now without waiting
+	Waiting   int // requests blocked waiting for the budget
+}
+
+// RateLimiter is a set of token buckets, one per endpoint group. It is safe for concurrent use.
+// Groups without a limit are not throttled.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[EndpointGroup]*bucket
+	now     func() time.Time
+}
+
+// bucket is a token bucket refilled continuously at limit.Requests per limit.Interval.
+// tokens goes negative while requests wait for reserved tokens.
+type bucket struct {
+	limit   RateLimit
+	tokens  float64
+	last    time.Time
+	waiting int
+}
+
+// NewRateLimiter creates a limiter with the given budgets (see DefaultRateLimits).
+func NewRateLimiter(limits map[EndpointGroup]RateLimit) *RateLimiter {
+	l := &RateLimiter{buckets: make(map[EndpointGroup]*bucket), now: time.Now}
+	for group, limit := range limits {
+		l.SetLimit(group, limit)
+	}
+	return l
+}
+
+// SetLimit sets the budget of group. A limit with no requests or interval removes the limit.
+func (l *RateLimiter) SetLimit(group EndpointGroup, limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit.Requests <= 0 || limit.Interval <= 0 {
+		delete(l.buckets, group)
+		return
+	}
+	b, ok := l.buckets[group]
+	if !ok {
+		l.buckets[group] = &bucket{limit: limit, tokens: float64(limit.Requests), last: l.now()}
+		return
+	}
+	b.refill(l.now())
+	b.limit = limit
+	b.tokens = min(b.tokens, float64(limit.Requests))
+}
+
+// Wait blocks until a request of group may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	l.mu.Lock()
+	b, ok := l.buckets[group]
+	if !ok {
+		l.mu.Unlock()
+		return nil
+	}
+	b.refill(l.now())
+	b.tokens--
+	if b.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := b.delay(-b.tokens)
+	b.waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		b.waiting--
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back
+		l.mu.Lock()
+		b.waiting--
+		b.refill(l.now())
+		b.tokens = min(b.tokens+1, float64(b.limit.Requests))
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Usage returns the current state of the group budget. ok is false if the group is not limited.
+func (l *RateLimiter) Usage(group EndpointGroup) (usage RateLimitUsage, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[group]
+	if !ok {
+		return RateLimitUsage{}, false
+	}
+	b.refill(l.now())
+	return RateLimitUsage{
+		Group:     group,
+		Limit:     b.limit,
+		Available: max(0, int(b.tokens)),
+		Waiting:   b.waiting,
+	}, true
+}
+
+// UsageAll returns the state of every limited group.
+func (l *RateLimiter) UsageAll() []RateLimitUsage {
+	l.mu.Lock()
+	groups := make([]EndpointGroup, 0, len(l.buckets))
+	for group := range l.buckets {
+		groups = append(groups, group)
+	}
+	l.mu.Unlock()
+	usages := make([]RateLimitUsage, 0, len(groups))
+	for _, group := range groups {
+		if usage, ok := l.Usage(group); ok {
+			usages = append(usages, usage)
+		}
+	}
+	return usages
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens = min(b.tokens+elapsed.Seconds()*b.rate(), float64(b.limit.Requests))
+}
+
+// rate returns the refill rate in tokens per second.
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Requests) / b.limit.Interval.Seconds()
+}
+
+// delay returns the time needed to accumulate n tokens.
+func (b *bucket) delay(n float64) time.Duration {
+	return time.Duration(n / b.rate() * float64(time.Second))
+}
+
+// endpointGroup returns the rate limit group of an endpoint path.
+func endpointGroup(path string) EndpointGroup {
+	switch {
+	case strings.HasPrefix(path, "/api/v1/trade/"):
+		return GroupTrading
+	case strings.HasPrefix(path, "/api/v1/account/"):
+		return GroupAccount
+	case strings.HasPrefix(path, "/api/v1/asset/"):
+		return GroupAsset
+	default:
+		return GroupPublic
+	}
+}
@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for RateLimiter.now.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		path string
+		want EndpointGroup
+	}{
+		{EndpointTickers, GroupPublic},
+		{EndpointPlaceOrder, GroupTrading},
+		{EndpointPositions, GroupAccount},
+		{EndpointAssetBalances, GroupAsset},
+		{EndpointTransfer, GroupAsset},
+		{"/api/v1/unknown", GroupPublic},
+	}
+	for _, tt := range tests {
+		if got := endpointGroup(tt.path); got != tt.want {
+			t.Errorf("endpointGroup(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiterUsage(t *testing.T) {
+	limit := RateLimit{Requests: 10, Interval: 10 * time.Second} // 1 token per second
+	tests := []struct {
+		name          string
+		requests      int           // sent at the start
+		elapsed       time.Duration // then
+		wantAvailable int
+	}{
+		{"full", 0, 0, 10},
+		{"spent", 4, 0, 6},
+		{"exhausted", 10, 0, 0},
+		{"refilled", 10, 3 * time.Second, 3},
+		{"partially refilled", 10, 2500 * time.Millisecond, 2},
+		{"capped at the limit", 2, time.Minute, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{t: time.Unix(1700000000, 0)}
+			l := &RateLimiter{buckets: make(map[EndpointGroup]*bucket), now: clock.now}
+			l.SetLimit(GroupTrading, limit)
+			for range tt.requests {
+				if err := l.Wait(context.Background(), GroupTrading); err != nil {
+					t.Fatal(err)
+				}
+			}
+			clock.t = clock.t.Add(tt.elapsed)
+			usage, ok := l.Usage(GroupTrading)
+			if !ok {
+				t.Fatal("group not limited")
+			}
+			if usage.Available != tt.wantAvailable || usage.Waiting != 0 || usage.Limit != limit {
+				t.Errorf("Usage = %+v, want %d available", usage, tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(map[EndpointGroup]RateLimit{GroupTrading: {Requests: 2, Interval: 200 * time.Millisecond}})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 4 {
+		if err := l.Wait(ctx, GroupTrading); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The burst of 2 is free, the next 2 wait for 100ms each
+	if d := time.Since(start); d < 180*time.Millisecond {
+		t.Errorf("4 requests took %v, want at least 200ms", d)
+	}
+
+	// A cancelled wait gives its token back
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(short, GroupTrading); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+	if usage, _ := l.Usage(GroupTrading); usage.Waiting != 0 {
+		t.Errorf("Waiting = %d after cancellation, want 0", usage.Waiting)
+	}
+
+	// Groups without a limit are not throttled
+	for range 100 {
+		if err := l.Wait(ctx, GroupPublic); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := l.Usage(GroupPublic); ok {
+		t.Error("Usage reported a limit for an unlimited group")
+	}
+	l.SetLimit(GroupTrading, RateLimit{})
+	if _, ok := l.Usage(GroupTrading); ok {
+		t.Error("SetLimit with a zero limit did not remove it")
+	}
+}
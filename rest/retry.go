@@ -0,0 +1,81 @@
+// Package rest provides the retry policy for transient REST failures.
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mmavka/go-blofin/internal/backoff"
+)
+
+// RetryPolicy configures retries of idempotent (GET) requests failed with a transient error
+// (see RequestError.Temporary). The delay before retry n is InitialDelay * Multiplier^(n-1),
+// capped at MaxDelay, randomized by ±Jitter (fraction of the delay, 0..1). A longer Retry-After
+// sent by the server takes precedence.
+type RetryPolicy struct {
+	MaxRetries   int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+}
+
+// DefaultRetryPolicy returns a policy with 3 retries, 200ms initial delay, 5s max delay,
+// multiplier 2 and 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:   3,
+		InitialDelay: 200 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// backoff returns the delay before the given retry (starting from 1).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	return backoff.Delay(retry, p.InitialDelay, p.MaxDelay, p.Multiplier, p.Jitter)
+}
+
+// SetRetryPolicy sets the retry policy of GET requests (nil disables retries).
+// POST requests are never retried, as they may have been executed.
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// shouldRetry returns the delay before the next attempt of a failed request and whether to retry.
+func (c *Client) shouldRetry(method string, retry int, err error) (time.Duration, bool) {
+	reqErr, ok := err.(*RequestError)
+	if !ok || c.retryPolicy == nil || method != http.MethodGet || retry > c.retryPolicy.MaxRetries || !reqErr.Temporary() {
+		return 0, false
+	}
+	return max(c.retryPolicy.backoff(retry), reqErr.RetryAfter), true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+// Package rest provides REST API client for private trading endpoints.
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+)
+
+// MaxBatchOrders is the maximum number of orders in one batch place/cancel request.
+const MaxBatchOrders = 20
+
+// PlaceOrder places a new order.
+// Required: instId, marginMode, positionSide, side, orderType, size; price for non-market orders.
+func (c *Client) PlaceOrder(ctx context.Context, req models.PlaceOrderRequest) (*models.OrderResult, error) {
+	if err := validatePlaceOrder(req); err != nil {
+		return nil, err
+	}
+	var results []models.OrderResult
+	err := c.doSignedPost(ctx, EndpointPlaceOrder, req, &results)
+	if err != nil {
+		return nil, err
+	}
+	return firstOrderResult(results)
+}
+
+// PlaceBatchOrders places up to 20 orders in one request. All orders must share instId and marginMode.
+// Each order is validated like in PlaceOrder. Results are returned per order and must be checked by Code.
+func (c *Client) PlaceBatchOrders(ctx context.Context, reqs []models.PlaceOrderRequest) ([]models.OrderResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.New("orders are required")
+	}
+	if len(reqs) > MaxBatchOrders {
+		return nil, errors.New("orders must be at most 20")
+	}
+	for _, req := range reqs {
+		if err := validatePlaceOrder(req); err != nil {
+			return nil, err
+		}
+		if req.InstID != reqs[0].InstID {
+			return nil, errors.New("orders must have the same instId")
+		}
+		if req.MarginMode != reqs[0].MarginMode {
+			return nil, errors.New("orders must have the same marginMode")
+		}
+	}
+	var results []models.OrderResult
+	err := c.doSignedPost(ctx, EndpointPlaceBatchOrders, reqs, &results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CancelOrder cancels an order by orderId or clientOrderId.
+func (c *Client) CancelOrder(ctx context.Context, req models.CancelOrderRequest) (*models.OrderResult, error) {
+	if req.OrderID == "" && req.ClientOrderID == "" {
+		return nil, errors.New("orderId or clientOrderId is required")
+	}
+	var results []models.OrderResult
+	err := c.doSignedPost(ctx, EndpointCancelOrder, req, &results)
+	if err != nil {
+		return nil, err
+	}
+	return firstOrderResult(results)
+}
+
+// CancelBatchOrders cancels up to 20 orders in one request.
+// Results are returned per order and must be checked by Code.
+func (c *Client) CancelBatchOrders(ctx context.Context, reqs []models.CancelOrderRequest) ([]models.OrderResult, error) {
+	if len(reqs) == 0 {
+		return nil, errors.New("orders are required")
+	}
+	if len(reqs) > MaxBatchOrders {
+		return nil, errors.New("orders must be at most 20")
+	}
+	for _, req := range reqs {
+		if req.OrderID == "" && req.ClientOrderID == "" {
+			return nil, errors.New("orderId or clientOrderId is required")
+		}
+	}
+	var results []models.OrderResult
+	err := c.doSignedPost(ctx, EndpointCancelBatchOrders, reqs, &results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetActiveOrders fetches incomplete (live and partially filled) orders.
+// Params: instId, orderType, state, before, after, limit (optional)
+func (c *Client) GetActiveOrders(ctx context.Context, params url.Values) ([]models.Order, error) {
+	if err := validateParams(EndpointActiveOrders, params); err != nil {
+		return nil, err
+	}
+	var orders []models.Order
+	err := c.doSignedGet(ctx, EndpointActiveOrders, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetOrderDetail fetches a single order. Returns an error matching blofin.ErrOrderNotFound if there is no such order.
+// Params: instId (required), orderId or clientOrderId (one is required)
+func (c *Client) GetOrderDetail(ctx context.Context, params url.Values) (*models.Order, error) {
+	if err := validateParams(EndpointOrderDetail, params); err != nil {
+		return nil, err
+	}
+	var orders []models.Order
+	err := c.doSignedGet(ctx, EndpointOrderDetail, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		id := params.Get("orderId")
+		if id == "" {
+			id = params.Get("clientOrderId")
+		}
+		return nil, fmt.Errorf("%w: %s", blofin.ErrOrderNotFound, id)
+	}
+	return &orders[0], nil
+}
+
+// validatePlaceOrder checks required fields of an order request.
+func validatePlaceOrder(req models.PlaceOrderRequest) error {
+	if req.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if req.MarginMode == "" {
+		return errors.New("marginMode is required")
+	}
+	if req.PositionSide == "" {
+		return errors.New("positionSide is required")
+	}
+	if req.Side == "" {
+		return errors.New("side is required")
+	}
+	if req.OrderType == "" {
+		return errors.New("orderType is required")
+	}
+	if req.Size == "" {
+		return errors.New("size is required")
+	}
+	if req.OrderType != models.OrderTypeMarket && req.Price == "" {
+		return errors.New("price is required for non-market orders")
+	}
+	if req.ReduceOnly != "" && req.ReduceOnly != "true" && req.ReduceOnly != "false" {
+		return errors.New("reduceOnly must be true or false")
+	}
+	return nil
+}
+
+// orderResult is a per-order result item carrying its own code and message.
+type orderResult interface {
+	Status() (code, msg string)
+}
+
+// firstOrderResult returns the first result item, converting a failed item into *models.ApiError.
+func firstOrderResult[T orderResult](results []T) (*T, error) {
+	if len(results) == 0 {
+		return nil, errors.New("empty response data")
+	}
+	res := results[0]
+	if code, msg := res.Status(); code != "" && code != CodeSuccess {
+		return &res, &models.ApiError{Code: code, Message: msg}
+	}
+	return &res, nil
+}
+
+// GetOrdersHistory fetches completed (filled and canceled) orders.
+// Params: instId, orderType, state, before, after, begin, end, limit (optional)
+// before/after take an orderId cursor (see models.Order.Cursor).
+func (c *Client) GetOrdersHistory(ctx context.Context, params url.Values) ([]models.Order, error) {
+	if err := validateParams(EndpointOrdersHistory, params); err != nil {
+		return nil, err
+	}
+	var orders []models.Order
+	err := c.doSignedGet(ctx, EndpointOrdersHistory, params, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetFillsHistory fetches trade fills.
+// Params: instId, orderId, before, after, begin, end, limit (optional)
+// before/after take a tradeId cursor (see models.Fill.Cursor).
+func (c *Client) GetFillsHistory(ctx context.Context, params url.Values) ([]models.Fill, error) {
+	if err := validateParams(EndpointFillsHistory, params); err != nil {
+		return nil, err
+	}
+	var fills []models.Fill
+	err := c.doSignedGet(ctx, EndpointFillsHistory, params, &fills)
+	if err != nil {
+		return nil, err
+	}
+	return fills, nil
+}
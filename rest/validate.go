@@ -0,0 +1,100 @@
+// Package rest provides validation of query params: required params and numeric limits of every GET endpoint.
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// paramRule describes the query params of an endpoint.
+type paramRule struct {
+	// required lists required params; "a|b" means at least one of a and b
+	required []string
+	// max lists numeric params with their maximum value, checked in order
+	max []paramMax
+}
+
+// paramMax is the range 1..max of a numeric param.
+type paramMax struct {
+	name string
+	max  int
+}
+
+// maxOf returns the maximum value of the numeric param name, or 0 if it has none.
+func (r paramRule) maxOf(name string) int {
+	for _, m := range r.max {
+		if m.name == name {
+			return m.max
+		}
+	}
+	return 0
+}
+
+// maxLimit100 is the limit rule shared by most paged endpoints.
+var maxLimit100 = []paramMax{{"limit", 100}}
+
+// paramRules holds the query param rules by endpoint.
+var paramRules = map[string]paramRule{
+	EndpointInstruments:     {},
+	EndpointTickers:         {},
+	EndpointMarkPrice:       {},
+	EndpointFundingRate:     {},
+	EndpointCandlesticks:    {required: []string{"instId"}, max: []paramMax{{"limit", 1440}}},
+	EndpointOrderBook:       {required: []string{"instId"}, max: []paramMax{{"size", 100}}},
+	EndpointTrades:          {required: []string{"instId"}, max: maxLimit100},
+	EndpointFundingRateHist: {required: []string{"instId"}, max: maxLimit100},
+
+	EndpointActiveOrders:  {max: maxLimit100},
+	EndpointOrderDetail:   {required: []string{"instId", "orderId|clientOrderId"}},
+	EndpointOrdersHistory: {max: maxLimit100},
+	EndpointFillsHistory:  {max: maxLimit100},
+
+	EndpointActiveTPSL:        {max: maxLimit100},
+	EndpointTPSLHistory:       {max: maxLimit100},
+	EndpointActiveAlgoOrders:  {required: []string{"orderType"}, max: maxLimit100},
+	EndpointAlgoOrdersHistory: {required: []string{"orderType"}, max: maxLimit100},
+
+	EndpointPositions:        {},
+	EndpointPositionsHistory: {max: maxLimit100},
+	EndpointLeverageInfo:     {required: []string{"instId", "marginMode"}},
+	EndpointBills:            {max: maxLimit100},
+
+	EndpointAssetBalances:     {required: []string{"accountType"}},
+	EndpointTransferHistory:   {max: maxLimit100},
+	EndpointDepositHistory:    {max: maxLimit100},
+	EndpointWithdrawalHistory: {max: maxLimit100},
+}
+
+// validateParams checks params against the rules of endpoint.
+func validateParams(endpoint string, params url.Values) error {
+	rule := paramRules[endpoint]
+	for _, req := range rule.required {
+		names := strings.Split(req, "|")
+		found := false
+		for _, name := range names {
+			if params.Get(name) != "" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New(strings.Join(names, " or ") + " is required")
+		}
+	}
+	for _, m := range rule.max {
+		if params.Get(m.name) == "" {
+			continue
+		}
+		v, err := strconv.Atoi(params.Get(m.name))
+		if err != nil {
+			return fmt.Errorf("%s must be a number", m.name)
+		}
+		if v < 1 || v > m.max {
+			return fmt.Errorf("%s must be between 1 and %d", m.name, m.max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements login for private WebSocket connections.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/internal/auth"
+)
+
+// loginPath is the request path used in the login signature prehash.
+const loginPath = "/users/self/verify"
+
+// loginTimeout is used when the Connect context has no deadline.
+const loginTimeout = 10 * time.Second
+
+// Credentials holds the API key, secret and passphrase used to log in to the private endpoint.
+type Credentials struct {
+	APIKey     string
+	SecretKey  string
+	Passphrase string
+	// Environment is the environment the API key was created for (zero if not declared).
+	// Connect of a client in another environment is refused (see blofin.CheckCredentials).
+	Environment blofin.Environment
+}
+
+// LoginError is returned when the server rejects the login request.
+type LoginError struct {
+	Code    string
+	Message string
+}
+
+func (e *LoginError) Error() string {
+	return fmt.Sprintf("login failed: %s (code %s)", e.Message, e.Code)
+}
+
+// Unwrap returns the sentinel error of the code, or blofin.ErrLoginFailed if it is unknown.
+func (e *LoginError) Unwrap() error {
+	if err := blofin.ErrorForCode(e.Code); err != nil {
+		return err
+	}
+	return blofin.ErrLoginFailed
+}
+
+// login sends the signed login op and waits for the server response.
+// It must be called before readLoop is started.
+func (c *Client) login(ctx context.Context, conn *websocket.Conn) error {
+	timestamp := auth.Timestamp()
+	nonce := auth.Nonce()
+	req := map[string]interface{}{
+		"op": OpLogin,
+		"args": []map[string]string{{
+			"apiKey":     c.creds.APIKey,
+			"passphrase": c.creds.Passphrase,
+			"timestamp":  timestamp,
+			"sign":       auth.Sign(c.creds.SecretKey, loginPath+"GET"+timestamp+nonce),
+			"nonce":      nonce,
+		}},
+	}
+	msg, _ := json.Marshal(req)
+	if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(loginTimeout)
+	}
+	conn.SetReadDeadline(deadline)
+	defer conn.SetReadDeadline(time.Time{})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Event string `json:"event"`
+			Code  string `json:"code"`
+			Msg   string `json:"msg"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			continue
+		}
+		switch resp.Event {
+		case EventLogin:
+			if resp.Code != "" && resp.Code != "0" {
+				return &LoginError{Code: resp.Code, Message: resp.Msg}
+			}
+			return nil
+		case EventError:
+			return &LoginError{Code: resp.Code, Message: resp.Msg}
+		}
+	}
+}
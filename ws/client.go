@@ -2,128 +2,285 @@
 //
 // This file implements the base WebSocket client, message routing, and logging.
 //
-// NOTE: Reconnect logic is NOT implemented in the library. Connection loss and errors are returned to the caller.
-// The application is responsible for reconnecting and resubscribing if needed.
+// Reconnect is opt-in: without a ReconnectPolicy connection loss is reported to the error handler and
+// the application is responsible for reconnecting. With SetReconnectPolicy the client re-dials on
+// connection loss, logs in again (private connections) and replays all recorded subscriptions, keeping
+// existing handlers and returned channels.
 package ws
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	blofin "github.com/mmavka/go-blofin"
 	"github.com/mmavka/go-blofin/models"
 )
 
-type subscription struct {
-	channel string
-	instID  string
-	stype   string // "callback" или "channel"
-	handler any    // func(models.WSCandlestickMsg) или nil
-	ch      any    // chan models.WSCandlestickMsg или nil
-}
-
 // Client is a base WebSocket client with reconnect and logging support.
 type Client struct {
 	url  string
 	conn *websocket.Conn
-	subs map[string]struct{}
-
-	mu sync.Mutex
-
-	handlersCandles     map[string][]func(models.WSCandlestickMsg)
-	handlersTrades      map[string][]func(models.WSTradeMsg)
-	handlersTickers     map[string][]func(models.WSTickerMsg)
-	handlersOrderBook   map[string][]func(models.WSOrderBookMsg)
-	handlersFundingRate map[string][]func(models.WSFundingRateMsg)
-
-	channelsCandles     map[string]chan models.WSCandlestickMsg
-	channelsTrades      map[string]chan models.WSTradeMsg
-	channelsTickers     map[string]chan models.WSTickerMsg
-	channelsOrderBook   map[string]chan models.WSOrderBookMsg
-	channelsFundingRate map[string]chan models.WSFundingRateMsg
-
-	subscriptions []subscription
-	pingInterval  time.Duration
-	pongTimeout   time.Duration
-	lastPong      time.Time
-	ctx           context.Context
-	cancel        context.CancelFunc
-	wg            sync.WaitGroup
-	onError       func(error) // Error callback
+
+	mu      sync.Mutex
+	writeMu sync.Mutex // serializes writes to conn
+	opMu    sync.Mutex // orders upstream subscribe/unsubscribe requests with stream changes
+
+	streams map[string]streamEntry // Upstream subscriptions by channel:instID
+
+	creds        *Credentials       // nil for public connections
+	env          blofin.Environment // zero if url is not a known environment
+	dialer       websocket.Dialer
+	header       http.Header // handshake request headers
+	readLimit    int64       // 0 = unlimited
+	logger       *slog.Logger
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	lastPong     time.Time
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	onError      func(error) // Error callback
+
+	reconnectPolicy *ReconnectPolicy
+	onReconnect     func(attempt int, err error) // Called before each reconnect attempt
+	reconnecting    bool
+
+	pending []*pendingOp            // Subscribe/unsubscribe requests waiting for ack
+	onInfo  func(models.WSEventMsg) // Info event callback
+
+	delivery  DeliveryPolicy // Default delivery policy of new subscriptions
+	onRelease func()         // Called (must not block) when an upstream subscription is dropped; set by Pool
 }
 
-// NewClient creates a new WebSocket client.
-func NewClient(url string) *Client {
+// errNotConnected is returned when a request is sent before Connect.
+var errNotConnected = errors.New("websocket is not connected")
+
+// NewClient creates a new WebSocket client configured by opts.
+func NewClient(url string, opts ...Option) *Client {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Client{
-		url:                 url,
-		subs:                make(map[string]struct{}),
-		handlersCandles:     make(map[string][]func(models.WSCandlestickMsg)),
-		handlersTrades:      make(map[string][]func(models.WSTradeMsg)),
-		handlersTickers:     make(map[string][]func(models.WSTickerMsg)),
-		handlersOrderBook:   make(map[string][]func(models.WSOrderBookMsg)),
-		handlersFundingRate: make(map[string][]func(models.WSFundingRateMsg)),
-		channelsCandles:     make(map[string]chan models.WSCandlestickMsg),
-		channelsTrades:      make(map[string]chan models.WSTradeMsg),
-		channelsTickers:     make(map[string]chan models.WSTickerMsg),
-		channelsOrderBook:   make(map[string]chan models.WSOrderBookMsg),
-		channelsFundingRate: make(map[string]chan models.WSFundingRateMsg),
-		subscriptions:       []subscription{},
-		pingInterval:        25 * time.Second,
-		pongTimeout:         30 * time.Second,
-		lastPong:            time.Now(),
-		ctx:                 ctx,
-		cancel:              cancel,
+	c := &Client{
+		url:          url,
+		streams:      make(map[string]streamEntry),
+		pingInterval: PingIntervalSec * time.Second,
+		pongTimeout:  ConnTimeoutSec * time.Second,
+		dialer: websocket.Dialer{
+			Proxy:             http.ProxyFromEnvironment,
+			EnableCompression: false,
+			HandshakeTimeout:  45 * time.Second,
+		},
+		header:   make(http.Header),
+		logger:   slog.New(slog.DiscardHandler),
+		lastPong: time.Now(),
+		delivery: DefaultDeliveryPolicy(),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.env, _ = blofin.EnvironmentOf(url)
+	return c
+}
+
+// New creates a WebSocket client for env configured by opts: a private client (env.PrivateWSURL)
+// if WithCredentials is given, a public one (env.PublicWSURL) otherwise.
+func New(env blofin.Environment, opts ...Option) *Client {
+	c := NewClient(env.PublicWSURL, opts...)
+	if c.creds != nil {
+		c.url = env.PrivateWSURL
 	}
+	c.env = env
+	return c
+}
+
+// Environment returns the environment of the client, or the zero Environment if its URL is not
+// a known environment. Pass it to blofin.CheckEnvironments to verify the pairing with other clients.
+func (c *Client) Environment() blofin.Environment {
+	return c.env
+}
+
+// NewPrivateClient creates a new WebSocket client for the private endpoint (e.g. WSURLPrivateProd).
+// Connect performs the signed login before returning.
+func NewPrivateClient(url, apiKey, secretKey, passphrase string, opts ...Option) *Client {
+	opts = append(opts[:len(opts):len(opts)], WithCredentials(apiKey, secretKey, passphrase))
+	return NewClient(url, opts...)
 }
 
 // Connect establishes the WebSocket connection.
+// For private clients it also logs in and returns *LoginError if the server rejects the credentials.
 func (c *Client) Connect(ctx context.Context) error {
-	d := websocket.Dialer{
-		EnableCompression: false,
+	return c.dial(ctx)
+}
+
+// dial opens a new connection, logs in if required and starts the ping and read loops for it.
+func (c *Client) dial(ctx context.Context) error {
+	if c.creds != nil {
+		if err := blofin.CheckCredentials(c.creds.Environment, c.env); err != nil {
+			return err
+		}
 	}
-	conn, _, err := d.DialContext(ctx, c.url, nil)
+	d := c.dialer
+	conn, _, err := d.DialContext(ctx, c.url, c.header)
 	if err != nil {
 		return err
 	}
-	c.conn = conn
+	if c.readLimit > 0 {
+		conn.SetReadLimit(c.readLimit)
+	}
+
+	if c.creds != nil {
+		if err := c.login(ctx, conn); err != nil {
+			conn.Close()
+			return err
+		}
+	}
 
 	// Control frame handlers
-	c.conn.SetPingHandler(func(appData string) error {
-		return c.conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
+	conn.SetPingHandler(func(appData string) error {
+		return conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(time.Second))
 	})
-	c.conn.SetPongHandler(func(appData string) error {
+	conn.SetPongHandler(func(appData string) error {
+		c.mu.Lock()
 		c.lastPong = time.Now()
+		c.mu.Unlock()
 		return nil
 	})
 
+	c.mu.Lock()
+	if err := c.ctx.Err(); err != nil {
+		// Client was closed while dialing
+		c.mu.Unlock()
+		conn.Close()
+		return err
+	}
+	c.conn = conn
+	c.lastPong = time.Now()
+	c.reconnecting = false
+	c.mu.Unlock()
+	c.logger.Debug("websocket connected", "url", c.url)
+
 	c.wg.Add(1)
-	go c.pingLoop()
+	go c.pingLoop(conn)
 
 	c.wg.Add(1)
-	go c.readLoop()
+	go c.readLoop(conn)
 	return nil
 }
 
+// currentConn returns the active connection or nil.
+func (c *Client) currentConn() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// write sends a text message on the active connection.
+// While reconnecting the message is dropped: subscriptions are replayed once the connection is restored.
+func (c *Client) write(msg []byte) error {
+	c.mu.Lock()
+	conn, reconnecting := c.conn, c.reconnecting
+	c.mu.Unlock()
+	if conn == nil {
+		if reconnecting {
+			return nil
+		}
+		return errNotConnected
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, msg)
+}
+
+// opArg is a single argument of a subscribe/unsubscribe request.
+// instId is omitted when empty (private channels without instrument filter).
+type opArg struct {
+	Channel string `json:"channel"`
+	InstID  string `json:"instId,omitempty"`
+}
+
+// writeOp sends a subscribe/unsubscribe request for a single channel.
+func (c *Client) writeOp(op, channel, instID string) error {
+	return c.writeOps(op, []opArg{{Channel: channel, InstID: instID}})
+}
+
+// writeOps sends a subscribe/unsubscribe request for args, split into as few requests as possible
+// with the encoded args of each below MaxArgsSizeBytes.
+func (c *Client) writeOps(op string, args []opArg) error {
+	for _, batch := range batchArgs(args) {
+		req := map[string]interface{}{
+			"op":   op,
+			"args": batch,
+		}
+		msg, _ := json.Marshal(req)
+		if err := c.write(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchArgs splits args into batches whose JSON array encoding fits in MaxArgsSizeBytes.
+func batchArgs(args []opArg) [][]opArg {
+	var batches [][]opArg
+	start, size := 0, 2 // []
+	for i, arg := range args {
+		b, _ := json.Marshal(arg)
+		n := len(b)
+		if i > start {
+			n++ // comma
+		}
+		if i > start && size+n > MaxArgsSizeBytes {
+			batches = append(batches, args[start:i])
+			start, size = i, 2
+			n = len(b)
+		}
+		size += n
+	}
+	if start < len(args) {
+		batches = append(batches, args[start:])
+	}
+	return batches
+}
+
+// alive reports whether the client is connected or reconnecting and not closed.
+func (c *Client) alive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ctx.Err() == nil && (c.conn != nil || c.reconnecting)
+}
+
+// released notifies the owning Pool that an upstream subscription was dropped.
+func (c *Client) released() {
+	if c.onRelease != nil {
+		c.onRelease()
+	}
+}
+
 // pingLoop periodically sends ping and checks pong.
-func (c *Client) pingLoop() {
+func (c *Client) pingLoop(conn *websocket.Conn) {
 	defer c.wg.Done()
 	for {
 		select {
 		case <-time.After(c.pingInterval):
-			if err := c.conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(time.Second)); err != nil {
-				if c.onError != nil {
-					c.onError(err)
-				}
+			if c.currentConn() != conn {
+				return
+			}
+			if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(time.Second)); err != nil {
+				c.connLost(conn, err)
 				return
 			}
-			if time.Since(c.lastPong) > c.pongTimeout {
-				if c.onError != nil {
-					c.onError(fmt.Errorf("pong timeout"))
-				}
+			c.mu.Lock()
+			lastPong := c.lastPong
+			c.mu.Unlock()
+			if time.Since(lastPong) > c.pongTimeout {
+				c.connLost(conn, fmt.Errorf("pong timeout"))
 				return
 			}
 		case <-c.ctx.Done():
@@ -132,170 +289,70 @@ func (c *Client) pingLoop() {
 	}
 }
 
+// connLost handles the loss of conn. Only the first call for the active connection has effect:
+// the error is reported to the error handler or, if a reconnect policy is set, a reconnect is started.
+func (c *Client) connLost(conn *websocket.Conn, err error) {
+	c.mu.Lock()
+	if c.conn != conn || c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	reconnect := c.reconnectPolicy != nil
+	c.reconnecting = reconnect
+	if reconnect {
+		c.keepReplayed()
+	} else {
+		c.failPending(err)
+	}
+	if reconnect {
+		c.wg.Add(1)
+	}
+	c.mu.Unlock()
+	conn.Close()
+	c.logger.Warn("websocket connection lost", "url", c.url, "error", err, "reconnect", reconnect)
+
+	if reconnect {
+		go c.reconnect(err)
+		return
+	}
+	c.terminateStreams(err)
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
 // readLoop reads and dispatches incoming messages.
-func (c *Client) readLoop() {
+func (c *Client) readLoop(conn *websocket.Conn) {
 	defer c.wg.Done()
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
 		default:
-			_, msg, err := c.conn.ReadMessage()
+			_, msg, err := conn.ReadMessage()
 			if err != nil {
-				if c.onError != nil {
-					c.onError(err)
-				}
+				c.connLost(conn, err)
 				return
 			}
 			// Обработка pong
 			if string(msg) == `{"event":"pong"}` {
+				c.mu.Lock()
 				c.lastPong = time.Now()
+				c.mu.Unlock()
 				continue
 			}
 
 			// Универсальный парсер для определения канала
-			var base struct {
-				Arg struct {
-					Channel string `json:"channel"`
-					InstID  string `json:"instId"`
-				} `json:"arg"`
-				Data json.RawMessage `json:"data"`
-			}
-			if err := json.Unmarshal(msg, &base); err != nil || base.Arg.Channel == "" {
+			var base models.WSEventMsg
+			if err := json.Unmarshal(msg, &base); err != nil {
 				continue
 			}
-			ch := base.Arg.Channel
-			matched := false
-			switch {
-			case ch == "trades":
-				var tradeMsg models.WSTradeMsg
-				if err := json.Unmarshal(msg, &tradeMsg); err == nil {
-					c.dispatchTrade(tradeMsg)
-					matched = true
-				}
-			case ch == "tickers":
-				var tickerMsg models.WSTickerMsg
-				if err := json.Unmarshal(msg, &tickerMsg); err == nil {
-					c.dispatchTicker(tickerMsg)
-					matched = true
-				}
-			case ch == "books" || ch == "books5":
-				var obMsg models.WSOrderBookMsg
-				if err := json.Unmarshal(msg, &obMsg); err == nil {
-					c.dispatchOrderBook(obMsg)
-					matched = true
-				}
-			case ch == "fundingrate":
-				var frMsg models.WSFundingRateMsg
-				if err := json.Unmarshal(msg, &frMsg); err == nil {
-					c.dispatchFundingRate(frMsg)
-					matched = true
-				}
-			case len(ch) >= 6 && ch[:6] == "candle":
-				var wsMsg models.WSCandlestickMsg
-				if err := json.Unmarshal(msg, &wsMsg); err == nil {
-					c.dispatchCandlestick(wsMsg)
-					matched = true
-				}
-			}
-			if !matched {
+			// Server events (acks, errors, info) are not push messages
+			if c.handleEvent(base) || base.Arg.Channel == "" {
 				continue
 			}
-		}
-	}
-}
-
-// dispatchCandlestick routes candlestick messages to handlers and channels.
-func (c *Client) dispatchCandlestick(msg models.WSCandlestickMsg) {
-	key := msg.Arg.Channel + ":" + msg.Arg.InstID
-	c.mu.Lock()
-	handlers := c.handlersCandles[key]
-	ch := c.channelsCandles[key]
-	c.mu.Unlock()
-
-	for _, handler := range handlers {
-		go handler(msg)
-	}
-	if ch != nil {
-		select {
-		case ch <- msg:
-		default:
-		}
-	}
-}
-
-// dispatchTrade routes trade messages (заглушка)
-func (c *Client) dispatchTrade(msg models.WSTradeMsg) {
-	key := "trades:" + msg.Arg.InstID
-	c.mu.Lock()
-	handlers := c.handlersTrades[key]
-	ch := c.channelsTrades[key]
-	c.mu.Unlock()
-
-	for _, handler := range handlers {
-		go handler(msg)
-	}
-	if ch != nil {
-		select {
-		case ch <- msg:
-		default:
-		}
-	}
-}
-
-// dispatchTicker routes ticker messages (заглушка)
-func (c *Client) dispatchTicker(msg models.WSTickerMsg) {
-	key := "tickers:" + msg.Arg.InstID
-	c.mu.Lock()
-	handlers := c.handlersTickers[key]
-	ch := c.channelsTickers[key]
-	c.mu.Unlock()
-
-	for _, handler := range handlers {
-		go handler(msg)
-	}
-	if ch != nil {
-		select {
-		case ch <- msg:
-		default:
-		}
-	}
-}
-
-// dispatchOrderBook routes order book messages (заглушка)
-func (c *Client) dispatchOrderBook(msg models.WSOrderBookMsg) {
-	key := msg.Arg.Channel + ":" + msg.Arg.InstID
-	c.mu.Lock()
-	handlers := c.handlersOrderBook[key]
-	ch := c.channelsOrderBook[key]
-	c.mu.Unlock()
-
-	for _, handler := range handlers {
-		go handler(msg)
-	}
-	if ch != nil {
-		select {
-		case ch <- msg:
-		default:
-		}
-	}
-}
-
-// dispatchFundingRate routes funding rate messages (заглушка)
-func (c *Client) dispatchFundingRate(msg models.WSFundingRateMsg) {
-	key := "fundingrate:" + msg.Arg.InstID
-	c.mu.Lock()
-	handlers := c.handlersFundingRate[key]
-	ch := c.channelsFundingRate[key]
-	c.mu.Unlock()
-
-	for _, handler := range handlers {
-		go handler(msg)
-	}
-	if ch != nil {
-		select {
-		case ch <- msg:
-		default:
+			c.dispatch(base.Arg.Channel, base.Arg.InstID, msg)
 		}
 	}
 }
@@ -303,10 +360,11 @@ func (c *Client) dispatchFundingRate(msg models.WSFundingRateMsg) {
 // Close closes the WebSocket connection and завершает все goroutine.
 func (c *Client) Close() error {
 	c.cancel()
-	if c.conn != nil {
-		c.conn.Close()
+	if conn := c.currentConn(); conn != nil {
+		conn.Close()
 	}
 	c.wg.Wait()
+	c.terminateStreams(ErrClosed)
 	return nil
 }
 
@@ -0,0 +1,37 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+func TestConnectCredentialsEnvironment(t *testing.T) {
+	url := newTestServer(t, ackAll)
+	local := blofin.Environment{Name: "local", PublicWSURL: url, PrivateWSURL: url}
+	tests := []struct {
+		name    string
+		client  func() *Client
+		wantErr bool
+	}{
+		{"matching", func() *Client { return New(local, WithEnvCredentials(local, "k", "s", "p")) }, false},
+		{"undeclared", func() *Client { return NewPrivateClient(url, "k", "s", "p") }, false},
+		{"demo key with production", func() *Client { return New(blofin.Production, WithEnvCredentials(blofin.Demo, "k", "s", "p")) }, true},
+		{"key with custom URL", func() *Client { return NewClient(url, WithEnvCredentials(local, "k", "s", "p")) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.client()
+			defer c.Close()
+			err := c.Connect(context.Background())
+			if got := errors.Is(err, blofin.ErrEnvironmentMismatch); got != tt.wantErr {
+				t.Fatalf("err = %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
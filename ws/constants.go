@@ -3,10 +3,16 @@
 // This file defines channel names, limits, error codes, and other constants for Blofin WebSocket API.
 package ws
 
+import blofin "github.com/mmavka/go-blofin"
+
 const (
-	// WebSocket URLs
-	WSURLProd = "wss://openapi.blofin.com/ws/public"
-	WSURLDemo = "wss://demo-trading-openapi.blofin.com/ws/public"
+	// WebSocket URLs (see blofin.Production and blofin.Demo)
+	WSURLProd = blofin.ProdPublicWSURL
+	WSURLDemo = blofin.DemoPublicWSURL
+
+	// Private WebSocket URLs (login required)
+	WSURLPrivateProd = blofin.ProdPrivateWSURL
+	WSURLPrivateDemo = blofin.DemoPrivateWSURL
 
 	// Channel names (base)
 	ChannelTrades      = "trades"
@@ -14,7 +20,14 @@ const (
 	ChannelOrderBook   = "books"
 	ChannelFundingRate = "fundingrate"
 
+	// Private channel names
+	ChannelOrders     = "orders"
+	ChannelAlgoOrders = "orders-algo"
+	ChannelPositions  = "positions"
+	ChannelAccount    = "account"
+
 	// Event types
+	EventLogin       = "login"
 	EventSubscribe   = "subscribe"
 	EventUnsubscribe = "unsubscribe"
 	EventError       = "error"
@@ -60,6 +73,7 @@ const (
 
 // Subscription message format
 const (
+	OpLogin       = "login"
 	OpSubscribe   = "subscribe"
 	OpUnsubscribe = "unsubscribe"
 	OpPing        = "ping"
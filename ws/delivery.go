@@ -0,0 +1,142 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements ordered, back-pressure-aware delivery: every subscription has its own bounded
+// queue, callbacks are run one at a time by a per-subscription worker, and an OverflowPolicy decides
+// what happens when a consumer cannot keep up.
+package ws
+
+import (
+	"errors"
+)
+
+// ErrSlowConsumer is the Err of subscriptions ended by OverflowDisconnect.
+var ErrSlowConsumer = errors.New("subscription buffer overflow")
+
+// OverflowPolicy decides what happens to a push message when a subscription's buffer is full.
+type OverflowPolicy int
+
+// Overflow policies
+const (
+	// OverflowDropNewest discards the incoming message (default).
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered message to make room for the incoming one.
+	OverflowDropOldest
+	// OverflowBlock waits until the consumer makes room. This stalls the read loop and therefore
+	// every other subscription of the client, so the consumer must not call back into the client.
+	OverflowBlock
+	// OverflowDisconnect ends the subscription with ErrSlowConsumer.
+	OverflowDisconnect
+)
+
+// DeliveryPolicy configures the buffer of a subscription.
+type DeliveryPolicy struct {
+	Overflow   OverflowPolicy
+	BufferSize int // messages buffered per subscription, at least 1
+}
+
+// DefaultDeliveryPolicy returns a policy with a 100 message buffer that drops new messages on overflow.
+func DefaultDeliveryPolicy() DeliveryPolicy {
+	return DeliveryPolicy{
+		Overflow:   OverflowDropNewest,
+		BufferSize: chanBufferSize,
+	}
+}
+
+// SubscribeOption overrides the client delivery policy for a single subscription.
+type SubscribeOption func(*DeliveryPolicy)
+
+// WithOverflow sets the overflow policy of a subscription.
+func WithOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(p *DeliveryPolicy) {
+		p.Overflow = policy
+	}
+}
+
+// WithBufferSize sets the buffer size of a subscription.
+func WithBufferSize(n int) SubscribeOption {
+	return func(p *DeliveryPolicy) {
+		p.BufferSize = n
+	}
+}
+
+// SetDeliveryPolicy sets the delivery policy of subscriptions created afterwards.
+func (c *Client) SetDeliveryPolicy(policy DeliveryPolicy) {
+	c.mu.Lock()
+	c.delivery = policy
+	c.mu.Unlock()
+}
+
+// deliveryPolicy returns the client policy with opts applied.
+func (c *Client) deliveryPolicy(opts []SubscribeOption) DeliveryPolicy {
+	c.mu.Lock()
+	policy := c.delivery
+	c.mu.Unlock()
+	return applyDelivery(policy, opts)
+}
+
+// applyDelivery returns policy with opts applied.
+func applyDelivery(policy DeliveryPolicy, opts []SubscribeOption) DeliveryPolicy {
+	for _, opt := range opts {
+		opt(&policy)
+	}
+	if policy.BufferSize < 1 {
+		policy.BufferSize = 1
+	}
+	return policy
+}
+
+// deliver queues msg for the subscriber according to its overflow policy.
+// closed aborts a blocking send when the client is closed.
+// Returns false if the subscriber must be disconnected.
+func (sub *subscriber[T]) deliver(msg T, closed <-chan struct{}) bool {
+	sub.sendMu.Lock()
+	defer sub.sendMu.Unlock()
+	select {
+	case <-sub.done:
+		return true
+	default:
+	}
+
+	switch sub.overflow {
+	case OverflowBlock:
+		select {
+		case sub.ch <- msg:
+		case <-sub.done:
+		case <-closed:
+		}
+		return true
+	case OverflowDropOldest:
+		for {
+			select {
+			case sub.ch <- msg:
+				return true
+			default:
+			}
+			select {
+			case <-sub.ch:
+				sub.dropped.Add(1)
+			default:
+			}
+		}
+	}
+
+	select {
+	case sub.ch <- msg:
+		return true
+	default:
+	}
+	sub.dropped.Add(1)
+	return sub.overflow != OverflowDisconnect
+}
+
+// run calls the handler for each queued message in order until the subscriber is terminated.
+func (sub *subscriber[T]) run() {
+	for msg := range sub.ch {
+		select {
+		case <-sub.done:
+			return
+		default:
+		}
+		sub.handler(msg)
+	}
+}
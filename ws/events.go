@@ -0,0 +1,250 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements subscribe/unsubscribe acknowledgements and server event handling.
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+)
+
+// ErrConnectionLost is returned by requests that were waiting for acknowledgement when the
+// connection was lost and are not replayed after reconnect (unsubscribe requests: the upstream
+// subscription ended with the connection).
+var ErrConnectionLost = errors.New("websocket connection lost before acknowledgement")
+
+// ackTimeout bounds waiting for an acknowledgement when the passed context has no deadline.
+const ackTimeout = 10 * time.Second
+
+// SubscribeError is returned when the server rejects a subscribe or unsubscribe request.
+type SubscribeError struct {
+	Op      string
+	Channel string
+	InstID  string
+	Code    string
+	Message string
+}
+
+func (e *SubscribeError) Error() string {
+	return fmt.Sprintf("%s %s:%s failed: %s (code %s)", e.Op, e.Channel, e.InstID, e.Message, e.Code)
+}
+
+// Unwrap returns the sentinel error of the code (see blofin.ErrorForCode), or nil if it is unknown.
+func (e *SubscribeError) Unwrap() error {
+	return blofin.ErrorForCode(e.Code)
+}
+
+// Is reports whether target is blofin.ErrSubscribeFailed.
+func (e *SubscribeError) Is(target error) bool {
+	return target == blofin.ErrSubscribeFailed
+}
+
+// pendingOp is a subscribe/unsubscribe request waiting for the server response.
+type pendingOp struct {
+	op      string
+	channel string
+	instID  string
+	done    chan error // buffered, receives exactly one result
+}
+
+// SetInfoHandler sets the callback for "info" events sent by the server.
+func (c *Client) SetInfoHandler(handler func(models.WSEventMsg)) {
+	c.mu.Lock()
+	c.onInfo = handler
+	c.mu.Unlock()
+}
+
+// startOp registers a pending subscribe/unsubscribe request and sends it.
+// Returns a nil pendingOp if there is nothing to wait for (reconnecting: the request is replayed later).
+func (c *Client) startOp(op, channel, instID string) (*pendingOp, error) {
+	ps, err := c.startOps(op, []opArg{{Channel: channel, InstID: instID}})
+	if ps == nil {
+		return nil, err
+	}
+	return ps[0], err
+}
+
+// startOps registers a pending request per arg and sends them batched (see writeOps).
+// Returns pending ops in the order of args, or nil if there is nothing to wait for.
+func (c *Client) startOps(op string, args []opArg) ([]*pendingOp, error) {
+	ps := make([]*pendingOp, len(args))
+	for i, arg := range args {
+		ps[i] = &pendingOp{op: op, channel: arg.Channel, instID: arg.InstID, done: make(chan error, 1)}
+	}
+	c.mu.Lock()
+	if c.conn == nil && c.reconnecting && op == OpSubscribe {
+		// Subscriptions are replayed after reconnect: wait for the ack of the replay
+		c.pending = append(c.pending, ps...)
+		c.mu.Unlock()
+		return ps, nil
+	}
+	if c.conn == nil {
+		// Not connected, or unsubscribing while reconnecting (nothing to unsubscribe
+		// on the new connection): write reports the state, nothing to wait for
+		c.mu.Unlock()
+		return nil, c.writeOps(op, args)
+	}
+	c.pending = append(c.pending, ps...)
+	c.mu.Unlock()
+
+	if err := c.writeOps(op, args); err != nil {
+		for _, p := range ps {
+			c.removePending(p)
+		}
+		return nil, err
+	}
+	return ps, nil
+}
+
+// awaitOp waits for the server response to p.
+// Waiting is bounded by ctx (or ackTimeout if ctx has no deadline).
+// Returns *SubscribeError if the server responds with an error event.
+func (c *Client) awaitOp(ctx context.Context, p *pendingOp) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ackTimeout)
+		defer cancel()
+	}
+	select {
+	case err := <-p.done:
+		return err
+	case <-ctx.Done():
+		c.removePending(p)
+		return ctx.Err()
+	case <-c.ctx.Done():
+		c.removePending(p)
+		if p.op == OpUnsubscribe {
+			// Closing the connection (e.g. by a Pool rebalance) ends the server side subscription too
+			return nil
+		}
+		return c.ctx.Err()
+	}
+}
+
+// removePending removes p from the pending list.
+func (c *Client) removePending(p *pendingOp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, q := range c.pending {
+		if q == p {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// failPending completes all pending requests with err. Caller must hold c.mu.
+func (c *Client) failPending(err error) {
+	for _, p := range c.pending {
+		p.done <- err
+	}
+	c.pending = nil
+}
+
+// keepReplayed keeps the pending subscribe requests of recorded streams, which are acknowledged
+// when resubscribe replays them, and completes the others with ErrConnectionLost. Caller must hold c.mu.
+func (c *Client) keepReplayed() {
+	kept := c.pending[:0]
+	for _, p := range c.pending {
+		if _, ok := c.streams[streamKey(p.channel, p.instID)]; ok && p.op == OpSubscribe {
+			kept = append(kept, p)
+			continue
+		}
+		p.done <- ErrConnectionLost
+	}
+	clear(c.pending[len(kept):])
+	c.pending = kept
+}
+
+// takeFailed removes and returns the pending request an error event refers to, or nil if it
+// cannot be identified. An event without arg (as Blofin usually sends them) fails the oldest pending
+// request with the event op, since the server answers requests in order. An event with an arg but no
+// op is ambiguous if several requests for the arg are pending. Caller must hold c.mu.
+func (c *Client) takeFailed(ev models.WSEventMsg) *pendingOp {
+	if ev.Arg.Channel == "" {
+		for i, p := range c.pending {
+			if ev.Op == "" || p.op == ev.Op {
+				c.pending = append(c.pending[:i], c.pending[i+1:]...)
+				return p
+			}
+		}
+		return nil
+	}
+	idx := -1
+	for i, p := range c.pending {
+		if p.channel != ev.Arg.Channel || p.instID != ev.Arg.InstID || (ev.Op != "" && p.op != ev.Op) {
+			continue
+		}
+		if idx >= 0 {
+			// Ambiguous without the op
+			return nil
+		}
+		idx = i
+		if ev.Op != "" {
+			// Identical requests: the oldest fails first
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+	p := c.pending[idx]
+	c.pending = append(c.pending[:idx], c.pending[idx+1:]...)
+	return p
+}
+
+// handleEvent processes a server event message. Returns false if the message is not an event.
+func (c *Client) handleEvent(ev models.WSEventMsg) bool {
+	switch ev.Event {
+	case "":
+		return false
+	case EventSubscribe, EventUnsubscribe:
+		c.mu.Lock()
+		for i, p := range c.pending {
+			if p.op == ev.Event && p.channel == ev.Arg.Channel && p.instID == ev.Arg.InstID {
+				c.pending = append(c.pending[:i], c.pending[i+1:]...)
+				p.done <- nil
+				break
+			}
+		}
+		c.mu.Unlock()
+	case EventError:
+		// Login failures (e.g. session expired) are reported via the error handler
+		if ev.Code == ErrorCodeLoginFailed {
+			c.reportError(&LoginError{Code: ev.Code, Message: ev.Msg})
+			return true
+		}
+		c.mu.Lock()
+		p := c.takeFailed(ev)
+		c.mu.Unlock()
+		if p == nil {
+			c.reportError(&SubscribeError{Op: ev.Op, Channel: ev.Arg.Channel, InstID: ev.Arg.InstID, Code: ev.Code, Message: ev.Msg})
+			return true
+		}
+		p.done <- &SubscribeError{Op: p.op, Channel: p.channel, InstID: p.instID, Code: ev.Code, Message: ev.Msg}
+	case EventLogin:
+		if ev.Code != "" && ev.Code != "0" {
+			c.reportError(&LoginError{Code: ev.Code, Message: ev.Msg})
+		}
+	case EventInfo:
+		c.mu.Lock()
+		onInfo := c.onInfo
+		c.mu.Unlock()
+		if onInfo != nil {
+			onInfo(ev)
+		}
+	}
+	return true
+}
+
+// reportError passes err to the error handler if set.
+func (c *Client) reportError(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+	"github.com/mmavka/go-blofin/models"
+)
+
+func TestSubscribeRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		event map[string]any
+	}{
+		{"without arg", map[string]any{"event": "error", "code": "60018", "msg": "doesn't exist"}},
+		{"with op and arg", map[string]any{"event": "error", "op": "subscribe", "arg": opArg{Channel: "trades", InstID: "BAD"}, "code": "60018", "msg": "doesn't exist"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := newTestServer(t, func(c *testConn, req testRequest) {
+				if req.arg(0).InstID == "BAD" {
+					c.send(tt.event)
+					return
+				}
+				ackAll(c, req)
+			})
+			c := NewClient(url)
+			defer c.Close()
+			var mu sync.Mutex
+			var handled []error
+			c.SetErrorHandler(func(err error) {
+				mu.Lock()
+				handled = append(handled, err)
+				mu.Unlock()
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := c.Connect(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			_, err := Subscribe(ctx, c, TradesChannel, "BAD", func(models.WSTradeMsg) {})
+			var subErr *SubscribeError
+			if !errors.As(err, &subErr) {
+				t.Fatalf("err = %v, want *SubscribeError", err)
+			}
+			if !errors.Is(err, blofin.ErrSubscribeFailed) || !errors.Is(err, blofin.ErrChannelNotFound) {
+				t.Errorf("err = %v, want ErrSubscribeFailed and ErrChannelNotFound", err)
+			}
+			if subErr.InstID != "BAD" || subErr.Op != OpSubscribe {
+				t.Errorf("err = %+v", subErr)
+			}
+			if time.Since(start) > time.Second {
+				t.Errorf("Subscribe took %v", time.Since(start))
+			}
+
+			// The client keeps working
+			if _, err := Subscribe(ctx, c, TradesChannel, "BTC-USDT", func(models.WSTradeMsg) {}); err != nil {
+				t.Fatal(err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(handled) != 0 {
+				t.Errorf("error handler got %v", handled)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file defines the functional options of NewClient, NewPrivateClient and NewPool.
+package ws
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithPingInterval sets the interval between pings (default PingIntervalSec).
+func WithPingInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.pingInterval = d
+	}
+}
+
+// WithPongTimeout sets how long the connection may stay without pong before it is considered lost
+// (default ConnTimeoutSec).
+func WithPongTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.pongTimeout = d
+	}
+}
+
+// WithHandshakeTimeout sets the timeout of the WebSocket handshake (default 45s).
+func WithHandshakeTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.dialer.HandshakeTimeout = d
+	}
+}
+
+// WithReadLimit sets the maximum size of an incoming message in bytes (default unlimited).
+// A larger message closes the connection.
+func WithReadLimit(n int64) Option {
+	return func(c *Client) {
+		c.readLimit = n
+	}
+}
+
+// WithReadBufferSize sets the size of the connection read buffer in bytes.
+func WithReadBufferSize(n int) Option {
+	return func(c *Client) {
+		c.dialer.ReadBufferSize = n
+	}
+}
+
+// WithWriteBufferSize sets the size of the connection write buffer in bytes.
+func WithWriteBufferSize(n int) Option {
+	return func(c *Client) {
+		c.dialer.WriteBufferSize = n
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the connection.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		c.dialer.TLSClientConfig = config
+	}
+}
+
+// WithProxy sets the proxy of the connection (default http.ProxyFromEnvironment).
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(c *Client) {
+		c.dialer.Proxy = proxy
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the handshake request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.header.Set("User-Agent", userAgent)
+	}
+}
+
+// WithHeader adds a header sent with the handshake request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithLogger sets the logger of connection events. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+		c.logger = logger
+	}
+}
+
+// WithReconnectPolicy enables automatic reconnect (see SetReconnectPolicy).
+func WithReconnectPolicy(policy *ReconnectPolicy) Option {
+	return func(c *Client) {
+		c.reconnectPolicy = policy
+	}
+}
+
+// WithDeliveryPolicy sets the default delivery policy of subscriptions (see SetDeliveryPolicy).
+func WithDeliveryPolicy(policy DeliveryPolicy) Option {
+	return func(c *Client) {
+		c.delivery = policy
+	}
+}
+
+// WithErrorHandler sets the error callback (see SetErrorHandler).
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *Client) {
+		c.onError = handler
+	}
+}
+
+// WithCredentials makes the client log in with the given credentials on connect (see NewPrivateClient).
+func WithCredentials(apiKey, secretKey, passphrase string) Option {
+	return func(c *Client) {
+		c.creds = &Credentials{APIKey: apiKey, SecretKey: secretKey, Passphrase: passphrase}
+	}
+}
+
+// WithEnvCredentials is like WithCredentials for an API key created for env: Connect is refused
+// with blofin.ErrEnvironmentMismatch unless the client belongs to env (see New).
+func WithEnvCredentials(env blofin.Environment, apiKey, secretKey, passphrase string) Option {
+	return func(c *Client) {
+		c.creds = &Credentials{APIKey: apiKey, SecretKey: secretKey, Passphrase: passphrase, Environment: env}
+	}
+}
@@ -0,0 +1,267 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements a local order book maintained from the books channel with sequence checking.
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// Order book actions
+const (
+	OrderBookActionSnapshot = "snapshot"
+	OrderBookActionUpdate   = "update"
+)
+
+// LocalOrderBook is an order book kept in sync from the books channel.
+// Snapshots replace the book, updates are merged and zero-size levels are removed.
+// If an update's prevSeqId does not match the last seqId the book is marked unsynced and
+// the channel is resubscribed to receive a fresh snapshot; other consumers of the channel receive
+// the snapshot too and may miss updates meanwhile. All read methods are safe for concurrent use.
+type LocalOrderBook struct {
+	channel Channel[models.WSOrderBookMsg]
+	instID  string
+	sub     *Subscription[models.WSOrderBookMsg] // set before started is closed
+	started chan struct{}
+
+	mu      sync.RWMutex
+	asks    []models.OrderBookLevel // ascending by price
+	bids    []models.OrderBookLevel // descending by price
+	seqID   int64
+	ts      string
+	synced  bool
+	resyncs int
+
+	resyncing atomic.Bool
+}
+
+// WatchOrderBook subscribes to an order book channel (e.g. ChannelOrderBook) and maintains a local book.
+// Messages are applied in order from the read loop. Call Close on the returned book to stop watching.
+func (c *Client) WatchOrderBook(ctx context.Context, channel, instID string) (*LocalOrderBook, error) {
+	ob := &LocalOrderBook{channel: OrderBookChannel(channel), instID: instID, started: make(chan struct{})}
+	handle, err := subscribe(ctx, c, ob.channel, instID, newInlineSubscriber(ob.handle))
+	ob.sub = handle
+	close(ob.started)
+	if err != nil {
+		return nil, err
+	}
+	return ob, nil
+}
+
+// Close stops maintaining the book. The channel is unsubscribed if nothing else uses it.
+func (ob *LocalOrderBook) Close(ctx context.Context) error {
+	return ob.sub.unsubscribe(ctx)
+}
+
+// Done returns a channel that is closed when the book stops being maintained.
+func (ob *LocalOrderBook) Done() <-chan struct{} {
+	return ob.sub.Done()
+}
+
+// Err returns why the book stopped being maintained (see Subscription.Err).
+func (ob *LocalOrderBook) Err() error {
+	return ob.sub.Err()
+}
+
+// handle applies msg and resubscribes on a sequence gap to receive a fresh snapshot.
+// Called from the read loop, so resubscription runs in a separate goroutine.
+func (ob *LocalOrderBook) handle(msg models.WSOrderBookMsg) {
+	if ob.apply(msg) || !ob.resyncing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer ob.resyncing.Store(false)
+		ob.resync()
+	}()
+}
+
+// resync resubscribes the stream on the client currently carrying it (streams move between Pool
+// connections) and waits for both acks. Failures are passed to the client error handler; if the
+// server rejects the subscribe the stream ends with the error.
+func (ob *LocalOrderBook) resync() {
+	// A gap may be detected before WatchOrderBook returns
+	<-ob.started
+	if ob.sub == nil {
+		return
+	}
+	s := ob.sub.stream
+	key := streamKey(s.channel, s.instID)
+	c := s.lockClient()
+	c.mu.Lock()
+	current := c.streams[key] == s && c.conn != nil
+	c.mu.Unlock()
+	if !current {
+		// Unsubscribed, or reconnecting: the replayed subscribe brings a snapshot
+		c.opMu.Unlock()
+		return
+	}
+	unsub, err := c.startOp(OpUnsubscribe, s.channel, s.instID)
+	var sub *pendingOp
+	if err == nil {
+		sub, err = c.startOp(OpSubscribe, s.channel, s.instID)
+	}
+	c.opMu.Unlock()
+
+	for _, p := range []*pendingOp{unsub, sub} {
+		if p == nil {
+			continue
+		}
+		if opErr := c.awaitOp(context.Background(), p); opErr != nil && err == nil {
+			err = opErr
+		}
+	}
+	if err == nil {
+		return
+	}
+	err = fmt.Errorf("resync %s: %w", key, err)
+	c.reportError(err)
+	var subErr *SubscribeError
+	if errors.As(err, &subErr) && subErr.Op == OpSubscribe {
+		c.dropStream(key, s, err)
+	}
+}
+
+// InstID returns the instrument ID of the book.
+func (ob *LocalOrderBook) InstID() string {
+	return ob.instID
+}
+
+// Synced reports whether the book holds a consistent snapshot.
+func (ob *LocalOrderBook) Synced() bool {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.synced
+}
+
+// SeqID returns the sequence ID of the last applied message.
+func (ob *LocalOrderBook) SeqID() int64 {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.seqID
+}
+
+// Resyncs returns how many times a sequence gap forced a resync.
+func (ob *LocalOrderBook) Resyncs() int {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.resyncs
+}
+
+// BestBid returns the highest bid. ok is false if the book has no bids or is not synced.
+func (ob *LocalOrderBook) BestBid() (level models.OrderBookLevel, ok bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	if !ob.synced || len(ob.bids) == 0 {
+		return models.OrderBookLevel{}, false
+	}
+	return ob.bids[0], true
+}
+
+// BestAsk returns the lowest ask. ok is false if the book has no asks or is not synced.
+func (ob *LocalOrderBook) BestAsk() (level models.OrderBookLevel, ok bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	if !ob.synced || len(ob.asks) == 0 {
+		return models.OrderBookLevel{}, false
+	}
+	return ob.asks[0], true
+}
+
+// Top returns up to n best levels of each side (bids descending, asks ascending).
+// Both are empty if the book is not synced.
+func (ob *LocalOrderBook) Top(n int) (bids, asks []models.OrderBookLevel) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	if !ob.synced {
+		return nil, nil
+	}
+	n = max(n, 0)
+	return append([]models.OrderBookLevel(nil), ob.bids[:min(n, len(ob.bids))]...),
+		append([]models.OrderBookLevel(nil), ob.asks[:min(n, len(ob.asks))]...)
+}
+
+// Snapshot returns a copy of the full depth, or an empty book if the book is not synced.
+func (ob *LocalOrderBook) Snapshot() models.OrderBook {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	if !ob.synced {
+		return models.OrderBook{}
+	}
+	return models.OrderBook{
+		Asks: append([]models.OrderBookLevel(nil), ob.asks...),
+		Bids: append([]models.OrderBookLevel(nil), ob.bids...),
+		Ts:   ob.ts,
+	}
+}
+
+// apply merges a books channel message. Returns false if a sequence gap was detected.
+func (ob *LocalOrderBook) apply(msg models.WSOrderBookMsg) bool {
+	seqID, _ := strconv.ParseInt(msg.Data.SeqID, 10, 64)
+	prevSeqID, _ := strconv.ParseInt(msg.Data.PrevSeqID, 10, 64)
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	if msg.Action == "" || msg.Action == OrderBookActionSnapshot {
+		ob.asks = ob.asks[:0]
+		ob.bids = ob.bids[:0]
+		mergeLevels(&ob.asks, msg.Data.Asks, false)
+		mergeLevels(&ob.bids, msg.Data.Bids, true)
+		ob.seqID = seqID
+		ob.ts = msg.Data.TS
+		ob.synced = true
+		return true
+	}
+	if !ob.synced {
+		// Waiting for a snapshot after a gap
+		return true
+	}
+	if prevSeqID != ob.seqID {
+		ob.synced = false
+		ob.resyncs++
+		return false
+	}
+	mergeLevels(&ob.asks, msg.Data.Asks, false)
+	mergeLevels(&ob.bids, msg.Data.Bids, true)
+	ob.seqID = seqID
+	ob.ts = msg.Data.TS
+	return true
+}
+
+// mergeLevels applies [price, size] updates to a sorted side, removing levels with zero size.
+func mergeLevels(side *[]models.OrderBookLevel, updates [][]string, desc bool) {
+	for _, arr := range updates {
+		update, ok := models.ParseOrderBookLevel(arr)
+		if !ok {
+			continue
+		}
+		levels := *side
+		i := sort.Search(len(levels), func(i int) bool {
+			cmp := levels[i].Price.Cmp(update.Price)
+			if desc {
+				return cmp <= 0
+			}
+			return cmp >= 0
+		})
+		found := i < len(levels) && levels[i].Price.Equal(update.Price)
+		switch {
+		case update.Quantity.IsZero() && found:
+			*side = append(levels[:i], levels[i+1:]...)
+		case update.Quantity.IsZero():
+		case found:
+			levels[i].Quantity = update.Quantity
+		default:
+			levels = append(levels, models.OrderBookLevel{})
+			copy(levels[i+1:], levels[i:])
+			levels[i] = update
+			*side = levels
+		}
+	}
+}
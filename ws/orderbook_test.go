@@ -0,0 +1,196 @@
+package ws
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// bookMsg builds a books push message.
+func bookMsg(t *testing.T, action, prevSeqID, seqID string, asks, bids [][]string) models.WSOrderBookMsg {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"action": action,
+		"data": map[string]any{
+			"asks":      asks,
+			"bids":      bids,
+			"ts":        "1700000000000",
+			"prevSeqId": prevSeqID,
+			"seqId":     seqID,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg models.WSOrderBookMsg
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// prices returns the prices of levels.
+func prices(levels []models.OrderBookLevel) []string {
+	out := make([]string, len(levels))
+	for i, l := range levels {
+		out[i] = l.Price.String()
+	}
+	return out
+}
+
+func TestLocalOrderBookTop(t *testing.T) {
+	ob := &LocalOrderBook{}
+	ob.apply(bookMsg(t, OrderBookActionSnapshot, "0", "1",
+		[][]string{{"101", "1"}, {"102", "2"}, {"103", "3"}},
+		[][]string{{"100", "1"}, {"99", "2"}}))
+
+	tests := []struct {
+		n          int
+		bids, asks int
+	}{
+		{n: -1, bids: 0, asks: 0},
+		{n: 0, bids: 0, asks: 0},
+		{n: 2, bids: 2, asks: 2},
+		{n: 10, bids: 2, asks: 3},
+	}
+	for _, tt := range tests {
+		bids, asks := ob.Top(tt.n)
+		if len(bids) != tt.bids || len(asks) != tt.asks {
+			t.Errorf("Top(%d) = %d bids, %d asks; want %d, %d", tt.n, len(bids), len(asks), tt.bids, tt.asks)
+		}
+	}
+}
+
+func TestLocalOrderBookUnsyncedReaders(t *testing.T) {
+	ob := &LocalOrderBook{}
+	ob.apply(bookMsg(t, OrderBookActionSnapshot, "0", "1", [][]string{{"101", "1"}}, [][]string{{"100", "1"}}))
+	// Gap: prevSeqId 5 does not follow seqId 1
+	if ob.apply(bookMsg(t, OrderBookActionUpdate, "5", "6", nil, nil)) {
+		t.Fatal("apply accepted an update after a gap")
+	}
+
+	if bids, asks := ob.Top(5); bids != nil || asks != nil {
+		t.Errorf("Top while unsynced = %v, %v; want empty", prices(bids), prices(asks))
+	}
+	if snap := ob.Snapshot(); snap.Asks != nil || snap.Bids != nil || snap.Ts != "" {
+		t.Errorf("Snapshot while unsynced = %+v; want empty", snap)
+	}
+	if _, ok := ob.BestBid(); ok {
+		t.Error("BestBid while unsynced reported ok")
+	}
+	if _, ok := ob.BestAsk(); ok {
+		t.Error("BestAsk while unsynced reported ok")
+	}
+}
+
+func TestLocalOrderBookApply(t *testing.T) {
+	type step struct {
+		action             string
+		prevSeqID, seq     string
+		asks, bids         [][]string
+		wantApplied        bool
+		wantSynced         bool
+		wantAsks, wantBids []string // prices after the step
+	}
+	tests := []struct {
+		name        string
+		steps       []step
+		wantSeqID   int64
+		wantResyncs int
+	}{
+		{
+			name: "snapshot sorts sides and drops zero sizes",
+			steps: []step{
+				{action: OrderBookActionSnapshot, prevSeqID: "0", seq: "10",
+					asks:        [][]string{{"103", "1"}, {"101", "1"}, {"102", "0"}},
+					bids:        [][]string{{"99", "1"}, {"100", "2"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"101", "103"}, wantBids: []string{"100", "99"}},
+			},
+			wantSeqID: 10,
+		},
+		{
+			name: "update inserts, changes and deletes levels",
+			steps: []step{
+				{action: OrderBookActionSnapshot, prevSeqID: "0", seq: "10",
+					asks: [][]string{{"101", "1"}, {"103", "1"}}, bids: [][]string{{"100", "1"}, {"98", "1"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"101", "103"}, wantBids: []string{"100", "98"}},
+				{action: OrderBookActionUpdate, prevSeqID: "10", seq: "11",
+					asks:        [][]string{{"102", "5"}, {"101", "0"}, {"103", "2"}, {"104", "0"}},
+					bids:        [][]string{{"99", "1"}, {"100", "0"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"102", "103"}, wantBids: []string{"99", "98"}},
+			},
+			wantSeqID: 11,
+		},
+		{
+			name: "gap unsyncs until the next snapshot",
+			steps: []step{
+				{action: OrderBookActionSnapshot, prevSeqID: "0", seq: "10",
+					asks: [][]string{{"101", "1"}}, bids: [][]string{{"100", "1"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"101"}, wantBids: []string{"100"}},
+				{action: OrderBookActionUpdate, prevSeqID: "12", seq: "13",
+					asks:        [][]string{{"102", "1"}},
+					wantApplied: false, wantSynced: false},
+				// Updates are ignored while waiting for a snapshot
+				{action: OrderBookActionUpdate, prevSeqID: "13", seq: "14",
+					asks:        [][]string{{"105", "1"}},
+					wantApplied: true, wantSynced: false},
+				{action: OrderBookActionSnapshot, prevSeqID: "0", seq: "20",
+					asks: [][]string{{"110", "1"}}, bids: [][]string{{"109", "1"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"110"}, wantBids: []string{"109"}},
+				{action: OrderBookActionUpdate, prevSeqID: "20", seq: "21",
+					bids:        [][]string{{"108", "1"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"110"}, wantBids: []string{"109", "108"}},
+			},
+			wantSeqID:   21,
+			wantResyncs: 1,
+		},
+		{
+			name: "update before any snapshot is ignored",
+			steps: []step{
+				{action: OrderBookActionUpdate, prevSeqID: "0", seq: "1",
+					asks:        [][]string{{"101", "1"}},
+					wantApplied: true, wantSynced: false},
+			},
+		},
+		{
+			name: "malformed levels are skipped",
+			steps: []step{
+				{action: OrderBookActionSnapshot, prevSeqID: "0", seq: "1",
+					asks: [][]string{{"101", "1"}, {"x", "1"}, {"102"}}, bids: [][]string{{"100", "y"}},
+					wantApplied: true, wantSynced: true, wantAsks: []string{"101"}, wantBids: []string{}},
+			},
+			wantSeqID: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := &LocalOrderBook{}
+			for i, s := range tt.steps {
+				if got := ob.apply(bookMsg(t, s.action, s.prevSeqID, s.seq, s.asks, s.bids)); got != s.wantApplied {
+					t.Fatalf("step %d: apply = %v, want %v", i, got, s.wantApplied)
+				}
+				if got := ob.Synced(); got != s.wantSynced {
+					t.Fatalf("step %d: Synced = %v, want %v", i, got, s.wantSynced)
+				}
+				if !s.wantSynced {
+					continue
+				}
+				snap := ob.Snapshot()
+				if got := prices(snap.Asks); !slices.Equal(got, s.wantAsks) {
+					t.Errorf("step %d: asks = %v, want %v", i, got, s.wantAsks)
+				}
+				if got := prices(snap.Bids); !slices.Equal(got, s.wantBids) {
+					t.Errorf("step %d: bids = %v, want %v", i, got, s.wantBids)
+				}
+			}
+			if got := ob.SeqID(); got != tt.wantSeqID {
+				t.Errorf("SeqID = %d, want %d", got, tt.wantSeqID)
+			}
+			if got := ob.Resyncs(); got != tt.wantResyncs {
+				t.Errorf("Resyncs = %d, want %d", got, tt.wantResyncs)
+			}
+		})
+	}
+}
@@ -0,0 +1,616 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements Pool, which spreads subscriptions over several connections within the
+// Blofin limits (MaxSubscriptionsPerConn, MaxConnectionsPerIP, MaxArgsSizeBytes).
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+
+	blofin "github.com/mmavka/go-blofin"
+)
+
+// ErrPoolFull is returned when a subscription does not fit into the pool connection limits.
+var ErrPoolFull = errors.New("websocket pool is full")
+
+// Pool manages a set of connections to the same endpoint and places each subscription on a
+// connection with free capacity, opening new connections on demand. Subscribe requests are batched
+// per connection. After unsubscribes the pool moves subscriptions off the least loaded connection
+// and closes it whenever the others have room for them.
+//
+// Subscriptions are made with PoolSubscribe, PoolSubscribeChan and PoolSubscribeAll and are
+// ended with Subscription.Unsubscribe. A subscription being moved may miss or repeat a few messages.
+type Pool struct {
+	url   string
+	creds *Credentials // nil for public pools
+	opts  []Option     // options of every connection
+	env   blofin.Environment
+
+	mu       sync.Mutex
+	clients  []*Client
+	maxConns int
+	maxSubs  int
+	dialing  int             // connections being opened without p.mu
+	dialed   *sync.Cond      // signaled on p.mu when dialing ends
+	draining *Client         // connection being drained by Rebalance; gets no new streams
+	reserved map[*Client]int // slots reserved for streams being moved by Rebalance
+
+	rebalanceMu sync.Mutex // serializes Rebalance
+
+	reconnectPolicy *ReconnectPolicy
+	delivery        DeliveryPolicy
+	onError         func(error)
+
+	trigger chan struct{} // requests a rebalance
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+// PoolStats describes the current pool usage.
+type PoolStats struct {
+	Connections   int
+	Subscriptions int   // upstream subscriptions over all connections
+	PerConnection []int // upstream subscriptions per connection
+}
+
+// NewPool creates a pool for a public endpoint (e.g. WSURLProd). opts configure every connection;
+// WithDeliveryPolicy, WithReconnectPolicy, WithErrorHandler and WithCredentials apply to the pool.
+func NewPool(url string, opts ...Option) *Pool {
+	// Read the pool level settings from the options
+	cfg := &Client{header: make(http.Header), delivery: DefaultDeliveryPolicy()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	env, _ := blofin.EnvironmentOf(url)
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		url:             url,
+		env:             env,
+		creds:           cfg.creds,
+		opts:            opts,
+		maxConns:        MaxConnectionsPerIP,
+		maxSubs:         MaxSubscriptionsPerConn,
+		reserved:        make(map[*Client]int),
+		reconnectPolicy: cfg.reconnectPolicy,
+		delivery:        cfg.delivery,
+		onError:         cfg.onError,
+		trigger:         make(chan struct{}, 1),
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+	p.dialed = sync.NewCond(&p.mu)
+	return p
+}
+
+// NewEnvPool creates a pool for env configured by opts: a private pool (env.PrivateWSURL)
+// if WithCredentials is given, a public one (env.PublicWSURL) otherwise.
+func NewEnvPool(env blofin.Environment, opts ...Option) *Pool {
+	p := NewPool(env.PublicWSURL, opts...)
+	if p.creds != nil {
+		p.url = env.PrivateWSURL
+	}
+	p.env = env
+	return p
+}
+
+// Environment returns the environment of the pool, or the zero Environment if its URL is not
+// a known environment. Pass it to blofin.CheckEnvironments to verify the pairing with other clients.
+func (p *Pool) Environment() blofin.Environment {
+	return p.env
+}
+
+// NewPrivatePool creates a pool for the private endpoint (e.g. WSURLPrivateProd). Every connection logs in.
+func NewPrivatePool(url, apiKey, secretKey, passphrase string, opts ...Option) *Pool {
+	opts = append(opts[:len(opts):len(opts)], WithCredentials(apiKey, secretKey, passphrase))
+	return NewPool(url, opts...)
+}
+
+// SetLimits sets the maximum number of connections and of subscriptions per connection.
+// Defaults are MaxConnectionsPerIP and MaxSubscriptionsPerConn; lower them if other clients share the IP.
+// Must be called before the first subscription.
+func (p *Pool) SetLimits(maxConns, maxSubsPerConn int) {
+	p.mu.Lock()
+	p.maxConns = maxConns
+	p.maxSubs = maxSubsPerConn
+	p.mu.Unlock()
+}
+
+// SetReconnectPolicy sets the reconnect policy of connections opened afterwards (see Client.SetReconnectPolicy).
+func (p *Pool) SetReconnectPolicy(policy *ReconnectPolicy) {
+	p.mu.Lock()
+	p.reconnectPolicy = policy
+	p.mu.Unlock()
+}
+
+// SetDeliveryPolicy sets the delivery policy of subscriptions created afterwards.
+func (p *Pool) SetDeliveryPolicy(policy DeliveryPolicy) {
+	p.mu.Lock()
+	p.delivery = policy
+	p.mu.Unlock()
+}
+
+// SetErrorHandler sets the error callback of connections opened afterwards and for rebalance errors.
+func (p *Pool) SetErrorHandler(handler func(error)) {
+	p.mu.Lock()
+	p.onError = handler
+	p.mu.Unlock()
+}
+
+// Connect opens the first connection. Calling it is optional: connections are opened on demand.
+func (p *Pool) Connect(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune()
+	if len(p.clients) > 0 {
+		return nil
+	}
+	return p.open(ctx, 1)
+}
+
+// Close closes all connections. Their subscriptions end with ErrClosed.
+func (p *Pool) Close() error {
+	p.cancel()
+	p.wg.Wait()
+	p.mu.Lock()
+	clients := p.clients
+	p.clients = nil
+	p.mu.Unlock()
+	for _, c := range clients {
+		c.Close()
+	}
+	return nil
+}
+
+// Stats returns the current pool usage.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := PoolStats{Connections: len(p.clients)}
+	for _, c := range p.clients {
+		n := c.streamCount()
+		stats.Subscriptions += n
+		stats.PerConnection = append(stats.PerConnection, n)
+	}
+	return stats
+}
+
+// Rebalance moves the subscriptions of the least loaded connection to the others and closes it,
+// as long as they have room for them. It runs automatically after unsubscribes.
+func (p *Pool) Rebalance(ctx context.Context) error {
+	p.rebalanceMu.Lock()
+	defer p.rebalanceMu.Unlock()
+	for {
+		// Plan the moves under the lock, then subscribe and wait for the acks without it
+		p.mu.Lock()
+		src, moves := p.planDrain()
+		if src == nil {
+			p.mu.Unlock()
+			return nil
+		}
+		p.draining = src
+		for _, m := range moves {
+			p.reserved[m.dst] += m.n
+		}
+		p.mu.Unlock()
+
+		moved, err := p.drain(ctx, src, moves)
+
+		p.mu.Lock()
+		p.draining = nil
+		clear(p.reserved)
+		if moved {
+			// Server side subscriptions end with the connection
+			p.clients = slices.DeleteFunc(p.clients, func(c *Client) bool { return c == src })
+		}
+		p.mu.Unlock()
+		if moved {
+			src.Close()
+		}
+		if err != nil || !moved {
+			return err
+		}
+	}
+}
+
+// drainMove is a part of a drain: n streams moved to dst.
+type drainMove struct {
+	dst *Client
+	n   int
+}
+
+// planDrain picks the least loaded connection and spreads its streams over the others, fullest
+// first. Returns a nil src if there is nothing to drain or no room for it. Caller must hold p.mu.
+func (p *Pool) planDrain() (*Client, []drainMove) {
+	p.prune()
+	if len(p.clients) < 2 {
+		return nil, nil
+	}
+	src := p.clients[0]
+	for _, c := range p.clients[1:] {
+		if c.streamCount() < src.streamCount() {
+			src = c
+		}
+	}
+	targets := slices.DeleteFunc(slices.Clone(p.clients), func(c *Client) bool { return c == src })
+	slices.SortFunc(targets, func(a, b *Client) int { return b.streamCount() - a.streamCount() })
+	var moves []drainMove
+	left := src.streamCount()
+	for _, dst := range targets {
+		if left == 0 {
+			break
+		}
+		n := min(p.maxSubs-dst.streamCount(), left)
+		if n <= 0 {
+			continue
+		}
+		moves = append(moves, drainMove{dst: dst, n: n})
+		left -= n
+	}
+	if left > 0 {
+		return nil, nil
+	}
+	return src, moves
+}
+
+// open dials n connections and adds them to the pool. Caller must hold p.mu; it is released while
+// dialing. If other connections are being dialed it only waits for them, so the caller must plan
+// again. Returns ErrPoolFull if the connections would exceed the limit.
+func (p *Pool) open(ctx context.Context, n int) error {
+	if p.dialing > 0 {
+		p.dialed.Wait()
+		return nil
+	}
+	if len(p.clients)+n > p.maxConns {
+		return ErrPoolFull
+	}
+	p.dialing += n
+	p.mu.Unlock()
+	conns := make([]*Client, 0, n)
+	var err error
+	for range n {
+		var c *Client
+		if c, err = p.dial(ctx); err != nil {
+			break
+		}
+		conns = append(conns, c)
+	}
+	p.mu.Lock()
+	p.dialing -= n
+	p.dialed.Broadcast()
+	if p.ctx.Err() != nil {
+		for _, c := range conns {
+			c.Close()
+		}
+		return ErrClosed
+	}
+	p.clients = append(p.clients, conns...)
+	if !p.started && len(conns) > 0 {
+		p.started = true
+		p.wg.Add(1)
+		go p.run()
+	}
+	return err
+}
+
+// dial opens a new pool connection. Caller must not hold p.mu.
+func (p *Pool) dial(ctx context.Context) (*Client, error) {
+	c := NewClient(p.url, p.opts...)
+	c.creds = p.creds
+	c.env = p.env
+	p.mu.Lock()
+	c.reconnectPolicy = p.reconnectPolicy
+	c.onError = p.onError
+	p.mu.Unlock()
+	c.onRelease = p.released
+	if err := c.Connect(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// prune closes and removes connections that were lost for good. Caller must hold p.mu.
+func (p *Pool) prune() {
+	p.clients = slices.DeleteFunc(p.clients, func(c *Client) bool {
+		if c.alive() {
+			return false
+		}
+		c.Close()
+		return true
+	})
+}
+
+// released is called by pool connections when an upstream subscription is dropped.
+func (p *Pool) released() {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run rebalances the pool when requested until the pool is closed.
+func (p *Pool) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.trigger:
+			if err := p.Rebalance(p.ctx); err != nil && p.ctx.Err() == nil {
+				p.reportError(err)
+			}
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// reportError passes err to the error handler if set.
+func (p *Pool) reportError(err error) {
+	p.mu.Lock()
+	onError := p.onError
+	p.mu.Unlock()
+	if onError != nil {
+		onError(err)
+	}
+}
+
+// place assigns every instID of channel to a connection: the one already carrying it or one with
+// free capacity, opening connections as needed. Returns the connections in order of first use and
+// the indexes of instIDs per connection. Caller must hold p.mu; it is released while dialing.
+func (p *Pool) place(ctx context.Context, channel string, instIDs []string) ([]*Client, map[*Client][]int, error) {
+	for {
+		p.prune()
+		order, groups, overflow := p.assign(channel, instIDs)
+		if overflow == 0 {
+			return order, groups, nil
+		}
+		if err := p.open(ctx, (overflow+p.maxSubs-1)/p.maxSubs); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// assign is place without dialing: it returns the number of new streams that do not fit into the
+// current connections. Caller must hold p.mu.
+func (p *Pool) assign(channel string, instIDs []string) ([]*Client, map[*Client][]int, int) {
+	var order []*Client
+	groups := make(map[*Client][]int)
+	chosen := make(map[string]*Client) // connection of each instID in this batch
+	added := make(map[*Client]int)
+	overflow := make(map[string]bool)
+	assign := func(c *Client, i int) {
+		if _, ok := groups[c]; !ok {
+			order = append(order, c)
+		}
+		groups[c] = append(groups[c], i)
+		chosen[instIDs[i]] = c
+	}
+
+next:
+	for i, instID := range instIDs {
+		if c, ok := chosen[instID]; ok {
+			assign(c, i)
+			continue
+		}
+		key := streamKey(channel, instID)
+		for _, c := range p.clients {
+			// A stream on the draining connection is placed anew: it may be moved or closed meanwhile
+			if c != p.draining && c.hasStream(key) {
+				assign(c, i)
+				continue next
+			}
+		}
+		var target *Client
+		for _, c := range p.clients {
+			if c != p.draining && c.streamCount()+added[c]+p.reserved[c] < p.maxSubs {
+				target = c
+				break
+			}
+		}
+		if target == nil {
+			overflow[instID] = true
+			continue
+		}
+		added[target]++
+		assign(target, i)
+	}
+	return order, groups, len(overflow)
+}
+
+// drain moves the streams of src to the connections planned by planDrain. Returns false if src has
+// subscriptions still waiting for acknowledgement or streams left. Caller must not hold p.mu.
+func (p *Pool) drain(ctx context.Context, src *Client, moves []drainMove) (bool, error) {
+	src.opMu.Lock()
+	src.mu.Lock()
+	entries := make(map[string]streamEntry, len(src.streams))
+	for key, entry := range src.streams {
+		if !entry.settled() {
+			src.mu.Unlock()
+			src.opMu.Unlock()
+			return false, nil
+		}
+		entries[key] = entry
+	}
+	src.mu.Unlock()
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var ops []*pendingOp
+	var opClients []*Client
+	var err error
+	for _, m := range moves {
+		n := min(m.n, len(keys))
+		if n == 0 {
+			break
+		}
+		var ps []*pendingOp
+		ps, err = src.moveStreams(m.dst, keys[:n], entries)
+		for range ps {
+			opClients = append(opClients, m.dst)
+		}
+		ops = append(ops, ps...)
+		keys = keys[n:]
+		if err != nil {
+			break
+		}
+	}
+	src.opMu.Unlock()
+
+	for i, op := range ops {
+		if opErr := opClients[i].awaitOp(ctx, op); opErr != nil {
+			var subErr *SubscribeError
+			if errors.As(opErr, &subErr) {
+				c := opClients[i]
+				key := streamKey(op.channel, op.instID)
+				c.mu.Lock()
+				entry := c.streams[key]
+				c.mu.Unlock()
+				if entry != nil {
+					c.dropStream(key, entry, opErr)
+				}
+			}
+			if err == nil {
+				err = opErr
+			}
+		}
+	}
+	if err != nil || len(keys) > 0 {
+		return false, err
+	}
+	// Close src only if no stream was attached to it meanwhile
+	src.opMu.Lock()
+	defer src.opMu.Unlock()
+	return src.streamCount() == 0, nil
+}
+
+// moveStreams moves the streams with the given keys from c to dst and subscribes them on dst.
+// Caller must hold c.opMu. Returns the pending subscribe requests on dst.
+func (c *Client) moveStreams(dst *Client, keys []string, entries map[string]streamEntry) ([]*pendingOp, error) {
+	dst.opMu.Lock()
+	defer dst.opMu.Unlock()
+	args := make([]opArg, 0, len(keys))
+	c.mu.Lock()
+	dst.mu.Lock()
+	for _, key := range keys {
+		entry := entries[key]
+		delete(c.streams, key)
+		dst.streams[key] = entry
+		entry.setClient(dst)
+		channel, instID := entry.args()
+		args = append(args, opArg{Channel: channel, InstID: instID})
+	}
+	dst.mu.Unlock()
+	c.mu.Unlock()
+	return dst.startOps(OpSubscribe, args)
+}
+
+// streamCount returns the number of upstream subscriptions.
+func (c *Client) streamCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.streams)
+}
+
+// hasStream reports whether the client carries the stream with key.
+func (c *Client) hasStream(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.streams[key]
+	return ok
+}
+
+// subscribePool places instIDs on pool connections and subscribes a subscriber created by newSub
+// for each of them. Either all subscriptions succeed or none is kept.
+func subscribePool[T any](ctx context.Context, p *Pool, ch Channel[T], instIDs []string, newSub func() *subscriber[T]) ([]*Subscription[T], error) {
+	if ch.Private && p.creds == nil {
+		return nil, errNotPrivate
+	}
+	p.mu.Lock()
+	order, groups, err := p.place(ctx, ch.Name, instIDs)
+	if err != nil {
+		p.mu.Unlock()
+		return nil, err
+	}
+	atts := make([]*attachment[T], 0, len(order))
+	for _, c := range order {
+		ids := make([]string, len(groups[c]))
+		for j, i := range groups[c] {
+			ids[j] = instIDs[i]
+		}
+		var a *attachment[T]
+		a, err = attach(c, ch, ids, newSub)
+		if err != nil {
+			break
+		}
+		atts = append(atts, a)
+	}
+	p.mu.Unlock()
+
+	handles := make([]*Subscription[T], len(instIDs))
+	var ok []*attachment[T]
+	for j, a := range atts {
+		hs, waitErr := a.wait(ctx)
+		if waitErr != nil {
+			if err == nil {
+				err = waitErr
+			}
+			continue
+		}
+		ok = append(ok, a)
+		for k, i := range groups[order[j]] {
+			handles[i] = hs[k]
+		}
+	}
+	if err != nil {
+		for _, a := range ok {
+			a.rollback(ctx, nil)
+		}
+		return nil, err
+	}
+	return handles, nil
+}
+
+// PoolSubscribe subscribes to ch for instID on a pool connection and calls handler for each push message.
+func PoolSubscribe[T any](ctx context.Context, p *Pool, ch Channel[T], instID string, handler func(T), opts ...SubscribeOption) (*Subscription[T], error) {
+	policy := p.deliveryPolicy(opts)
+	handles, err := subscribePool(ctx, p, ch, []string{instID}, func() *subscriber[T] { return newSubscriber(handler, policy) })
+	if err != nil {
+		return nil, err
+	}
+	return handles[0], nil
+}
+
+// PoolSubscribeChan subscribes to ch for instID on a pool connection.
+// Push messages are delivered to the channel returned by C.
+func PoolSubscribeChan[T any](ctx context.Context, p *Pool, ch Channel[T], instID string, opts ...SubscribeOption) (*Subscription[T], error) {
+	policy := p.deliveryPolicy(opts)
+	handles, err := subscribePool(ctx, p, ch, []string{instID}, func() *subscriber[T] { return newSubscriber[T](nil, policy) })
+	if err != nil {
+		return nil, err
+	}
+	return handles[0], nil
+}
+
+// PoolSubscribeAll subscribes to ch for every instID with batched requests and calls handler for
+// each push message. Returns one Subscription per instID, in order. If any subscription fails none is kept.
+func PoolSubscribeAll[T any](ctx context.Context, p *Pool, ch Channel[T], instIDs []string, handler func(T), opts ...SubscribeOption) ([]*Subscription[T], error) {
+	policy := p.deliveryPolicy(opts)
+	return subscribePool(ctx, p, ch, instIDs, func() *subscriber[T] { return newSubscriber(handler, policy) })
+}
+
+// deliveryPolicy returns the pool policy with opts applied.
+func (p *Pool) deliveryPolicy(opts []SubscribeOption) DeliveryPolicy {
+	p.mu.Lock()
+	policy := p.delivery
+	p.mu.Unlock()
+	return applyDelivery(policy, opts)
+}
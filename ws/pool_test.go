@@ -0,0 +1,110 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPoolPlacementAndRebalance(t *testing.T) {
+	p := NewPool(newTestServer(t, ackAll))
+	defer p.Close()
+	p.SetLimits(3, 3)
+	ctx := context.Background()
+
+	ids := []string{"A", "B", "C", "D", "E", "F", "G"}
+	subs, err := PoolSubscribeAll(ctx, p, TradesChannel, ids, func(models.WSTradeMsg) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := p.Stats(); st.Connections != 3 || st.Subscriptions != 7 {
+		t.Fatalf("stats = %+v, want 3 connections and 7 subscriptions", st)
+	}
+	if _, err := PoolSubscribe(ctx, p, TradesChannel, "H", func(models.WSTradeMsg) {}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PoolSubscribe(ctx, p, TradesChannel, "I", func(models.WSTradeMsg) {}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PoolSubscribe(ctx, p, TradesChannel, "J", func(models.WSTradeMsg) {}); err != ErrPoolFull {
+		t.Fatalf("err = %v, want ErrPoolFull", err)
+	}
+
+	for _, s := range subs[:5] {
+		if err := s.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, "rebalance", func() bool {
+		st := p.Stats()
+		return st.Connections == 2 && st.Subscriptions == 4
+	})
+	for _, s := range subs[5:] {
+		select {
+		case <-s.Done():
+			t.Fatalf("%s ended: %v", s.InstID(), s.Err())
+		default:
+		}
+	}
+}
+
+func TestPoolConcurrentSubscribe(t *testing.T) {
+	p := NewPool(newTestServer(t, ackAll))
+	defer p.Close()
+	p.SetLimits(4, 5)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = PoolSubscribe(context.Background(), p, TradesChannel, fmt.Sprintf("I%02d", i), func(models.WSTradeMsg) {})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("subscribe %d: %v", i, err)
+		}
+	}
+	if st := p.Stats(); st.Connections != 4 || st.Subscriptions != 20 {
+		t.Fatalf("stats = %+v, want 4 connections and 20 subscriptions", st)
+	}
+}
+
+func TestPoolAssignSkipsDraining(t *testing.T) {
+	p := NewPool(newTestServer(t, ackAll))
+	defer p.Close()
+	p.SetLimits(2, 2)
+	if _, err := PoolSubscribeAll(context.Background(), p, TradesChannel, []string{"A", "B", "C"}, func(models.WSTradeMsg) {}); err != nil {
+		t.Fatal(err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	src, other := p.clients[0], p.clients[1]
+	if !src.hasStream(streamKey(ChannelTrades, "A")) {
+		t.Fatal("A is not on the first connection")
+	}
+	// A new subscriber of A must not be attached to a connection being drained
+	p.draining = src
+	order, groups, overflow := p.assign(ChannelTrades, []string{"A"})
+	if overflow != 0 || len(order) != 1 || order[0] != other || len(groups[other]) != 1 {
+		t.Fatalf("assign = %v %v %d, want A on the other connection", order, groups, overflow)
+	}
+}
@@ -0,0 +1,112 @@
+// Package ws provides private WebSocket API for Blofin.
+//
+// This file declares the private channels and contains subscription methods for orders, algo orders,
+// positions and account channels. These channels require a client created with NewPrivateClient.
+//
+// Every Subscribe* call adds a separate consumer; Unsubscribe* ends all consumers of the channel and instrument.
+// Use the generic Subscribe/SubscribeChan to get a Subscription handle per consumer.
+package ws
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// errNotPrivate is returned when a private channel is used on a public connection.
+var errNotPrivate = errors.New("private channels require a client created with NewPrivateClient")
+
+// Private channel descriptors
+var (
+	OrdersChannel     = NewPrivateChannel[models.WSOrderMsg](ChannelOrders)
+	AlgoOrdersChannel = NewPrivateChannel[models.WSAlgoOrderMsg](ChannelAlgoOrders)
+	PositionsChannel  = NewPrivateChannel[models.WSPositionMsg](ChannelPositions)
+	AccountChannel    = NewPrivateChannel[models.WSAccountMsg](ChannelAccount)
+)
+
+// SubscribeOrders subscribes to the orders channel with callback.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribeOrders(ctx context.Context, instID string, handler func(models.WSOrderMsg)) error {
+	_, err := Subscribe(ctx, c, OrdersChannel, instID, handler)
+	return err
+}
+
+// SubscribeOrdersChan subscribes to the orders channel and returns channel for messages.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribeOrdersChan(ctx context.Context, instID string) (<-chan models.WSOrderMsg, error) {
+	sub, err := SubscribeChan(ctx, c, OrdersChannel, instID)
+	if err != nil {
+		return nil, err
+	}
+	return sub.C(), nil
+}
+
+// UnsubscribeOrders unsubscribes from the orders channel and removes handlers/channels.
+func (c *Client) UnsubscribeOrders(ctx context.Context, instID string) error {
+	return Unsubscribe(ctx, c, OrdersChannel, instID)
+}
+
+// SubscribeAlgoOrders subscribes to the orders-algo channel with callback.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribeAlgoOrders(ctx context.Context, instID string, handler func(models.WSAlgoOrderMsg)) error {
+	_, err := Subscribe(ctx, c, AlgoOrdersChannel, instID, handler)
+	return err
+}
+
+// SubscribeAlgoOrdersChan subscribes to the orders-algo channel and returns channel for messages.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribeAlgoOrdersChan(ctx context.Context, instID string) (<-chan models.WSAlgoOrderMsg, error) {
+	sub, err := SubscribeChan(ctx, c, AlgoOrdersChannel, instID)
+	if err != nil {
+		return nil, err
+	}
+	return sub.C(), nil
+}
+
+// UnsubscribeAlgoOrders unsubscribes from the orders-algo channel and removes handlers/channels.
+func (c *Client) UnsubscribeAlgoOrders(ctx context.Context, instID string) error {
+	return Unsubscribe(ctx, c, AlgoOrdersChannel, instID)
+}
+
+// SubscribePositions subscribes to the positions channel with callback.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribePositions(ctx context.Context, instID string, handler func(models.WSPositionMsg)) error {
+	_, err := Subscribe(ctx, c, PositionsChannel, instID, handler)
+	return err
+}
+
+// SubscribePositionsChan subscribes to the positions channel and returns channel for messages.
+// instID - optional instrument ID filter (empty for all instruments).
+func (c *Client) SubscribePositionsChan(ctx context.Context, instID string) (<-chan models.WSPositionMsg, error) {
+	sub, err := SubscribeChan(ctx, c, PositionsChannel, instID)
+	if err != nil {
+		return nil, err
+	}
+	return sub.C(), nil
+}
+
+// UnsubscribePositions unsubscribes from the positions channel and removes handlers/channels.
+func (c *Client) UnsubscribePositions(ctx context.Context, instID string) error {
+	return Unsubscribe(ctx, c, PositionsChannel, instID)
+}
+
+// SubscribeAccount subscribes to the account channel with callback.
+func (c *Client) SubscribeAccount(ctx context.Context, handler func(models.WSAccountMsg)) error {
+	_, err := Subscribe(ctx, c, AccountChannel, "", handler)
+	return err
+}
+
+// SubscribeAccountChan subscribes to the account channel and returns channel for messages.
+func (c *Client) SubscribeAccountChan(ctx context.Context) (<-chan models.WSAccountMsg, error) {
+	sub, err := SubscribeChan(ctx, c, AccountChannel, "")
+	if err != nil {
+		return nil, err
+	}
+	return sub.C(), nil
+}
+
+// UnsubscribeAccount unsubscribes from the account channel and removes handlers/channels.
+func (c *Client) UnsubscribeAccount(ctx context.Context) error {
+	return Unsubscribe(ctx, c, AccountChannel, "")
+}
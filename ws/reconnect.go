@@ -0,0 +1,120 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements the opt-in reconnect policy with exponential backoff and subscription replay.
+package ws
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mmavka/go-blofin/internal/backoff"
+)
+
+// ErrReconnectFailed is reported to the error handler when all reconnect attempts have failed.
+var ErrReconnectFailed = errors.New("reconnect failed")
+
+// ReconnectPolicy configures automatic reconnect.
+// The delay before attempt n is InitialDelay * Multiplier^(n-1), capped at MaxDelay,
+// randomized by ±Jitter (fraction of the delay, 0..1).
+type ReconnectPolicy struct {
+	MaxAttempts  int // 0 = unlimited
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+}
+
+// DefaultReconnectPolicy returns a policy with unlimited attempts, 1s initial delay, 1m max delay,
+// multiplier 2 and 20% jitter.
+func DefaultReconnectPolicy() *ReconnectPolicy {
+	return &ReconnectPolicy{
+		MaxAttempts:  0,
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// backoff returns the delay before the given attempt (starting from 1).
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	return backoff.Delay(attempt, p.InitialDelay, p.MaxDelay, p.Multiplier, p.Jitter)
+}
+
+// SetReconnectPolicy enables automatic reconnect with the given policy (nil disables it).
+// Must be called before Connect.
+func (c *Client) SetReconnectPolicy(policy *ReconnectPolicy) {
+	c.mu.Lock()
+	c.reconnectPolicy = policy
+	c.mu.Unlock()
+}
+
+// SetReconnectHandler sets a callback invoked before each reconnect attempt with the attempt number
+// and the error that caused the previous connection or attempt to fail.
+func (c *Client) SetReconnectHandler(handler func(attempt int, err error)) {
+	c.mu.Lock()
+	c.onReconnect = handler
+	c.mu.Unlock()
+}
+
+// reconnect re-dials with backoff until it succeeds, attempts are exhausted or the client is closed.
+// On success all recorded subscriptions are replayed.
+func (c *Client) reconnect(cause error) {
+	defer c.wg.Done()
+	c.mu.Lock()
+	policy := c.reconnectPolicy
+	onReconnect := c.onReconnect
+	c.mu.Unlock()
+
+	err := cause
+	for attempt := 1; policy.MaxAttempts <= 0 || attempt <= policy.MaxAttempts; attempt++ {
+		if onReconnect != nil {
+			onReconnect(attempt, err)
+		}
+		c.logger.Info("websocket reconnecting", "url", c.url, "attempt", attempt, "error", err)
+		select {
+		case <-time.After(policy.backoff(attempt)):
+		case <-c.ctx.Done():
+			return
+		}
+		if err = c.dial(c.ctx); err == nil {
+			c.resubscribe()
+			return
+		}
+		if c.ctx.Err() != nil {
+			return
+		}
+	}
+
+	err = fmt.Errorf("%w: %w", ErrReconnectFailed, err)
+	c.mu.Lock()
+	c.reconnecting = false
+	c.failPending(err)
+	c.mu.Unlock()
+	c.logger.Error("websocket reconnect failed", "url", c.url, "error", err)
+	c.terminateStreams(err)
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+// resubscribe replays every upstream subscription on the current connection.
+func (c *Client) resubscribe() {
+	c.mu.Lock()
+	entries := make([]streamEntry, 0, len(c.streams))
+	for _, entry := range c.streams {
+		entries = append(entries, entry)
+	}
+	c.mu.Unlock()
+
+	args := make([]opArg, 0, len(entries))
+	for _, entry := range entries {
+		channel, instID := entry.args()
+		args = append(args, opArg{Channel: channel, InstID: instID})
+	}
+	// If the connection fails again the next reconnect replays the subscriptions
+	if err := c.writeOps(OpSubscribe, args); err != nil {
+		c.reportError(fmt.Errorf("replay subscriptions: %w", err))
+	}
+}
@@ -0,0 +1,83 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// testRequest is a request read by the fake server.
+type testRequest struct {
+	Op   string            `json:"op"`
+	Args []json.RawMessage `json:"args"`
+}
+
+// testConn is a server side connection of the fake server.
+type testConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+// send writes v as a JSON text message.
+func (c *testConn) send(v any) {
+	b, _ := json.Marshal(v)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.WriteMessage(websocket.TextMessage, b)
+}
+
+// arg decodes the i-th request arg.
+func (r testRequest) arg(i int) opArg {
+	var a opArg
+	json.Unmarshal(r.Args[i], &a)
+	return a
+}
+
+// newTestServer starts a fake Blofin WebSocket endpoint calling respond for every request and
+// returns its URL. Login requests are accepted.
+func newTestServer(t *testing.T, respond func(c *testConn, req testRequest)) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := &testConn{conn: conn}
+		go func() {
+			defer conn.Close()
+			for {
+				_, msg, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if string(msg) == "ping" {
+					continue
+				}
+				var req testRequest
+				if json.Unmarshal(msg, &req) != nil {
+					continue
+				}
+				if req.Op == "login" {
+					c.send(map[string]string{"event": "login", "code": "0"})
+					continue
+				}
+				respond(c, req)
+			}
+		}()
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// ackAll acknowledges every arg of req.
+func ackAll(c *testConn, req testRequest) {
+	for i := range req.Args {
+		c.send(map[string]any{"event": req.Op, "arg": req.arg(i)})
+	}
+}
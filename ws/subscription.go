@@ -0,0 +1,499 @@
+// Package ws provides WebSocket client functionality.
+//
+// This file implements the generic subscription core shared by all channels:
+// a Channel descriptor declares a channel and its message type, and Subscribe/SubscribeChan
+// return a Subscription handle per consumer. The upstream server subscription is reference counted:
+// it is sent when the first consumer subscribes and torn down when the last one leaves.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is the Err of subscriptions terminated by Client.Close.
+var ErrClosed = errors.New("websocket client closed")
+
+// Channel describes a WebSocket channel: its name, whether it requires login and the push message type T.
+type Channel[T any] struct {
+	Name    string
+	Private bool
+}
+
+// NewChannel declares a public channel with push messages of type T.
+func NewChannel[T any](name string) Channel[T] {
+	return Channel[T]{Name: name}
+}
+
+// NewPrivateChannel declares a private (login required) channel with push messages of type T.
+func NewPrivateChannel[T any](name string) Channel[T] {
+	return Channel[T]{Name: name, Private: true}
+}
+
+// chanBufferSize is the default buffer size of a subscription.
+const chanBufferSize = 100
+
+// subscriber is a single local consumer of a stream.
+type subscriber[T any] struct {
+	handler  func(T)        // callback or nil
+	ch       chan T         // queue drained by the worker (callbacks) or returned by C (channels); nil if inline
+	inline   bool           // handler is called synchronously from the read loop (in message order)
+	overflow OverflowPolicy // what to do when ch is full
+	dropped  atomic.Uint64  // messages discarded by the overflow policy
+	evicting atomic.Bool    // set once a disconnect for overflow has been started
+	sendMu   sync.Mutex     // serializes sends to ch with closing it
+	done     chan struct{}  // closed when the subscriber is terminated
+	err      error          // reason of termination, guarded by the stream lock
+}
+
+// newSubscriber creates a callback subscriber (handler != nil) or a channel subscriber with a buffer per policy.
+func newSubscriber[T any](handler func(T), policy DeliveryPolicy) *subscriber[T] {
+	return &subscriber[T]{
+		handler:  handler,
+		ch:       make(chan T, policy.BufferSize),
+		overflow: policy.Overflow,
+		done:     make(chan struct{}),
+	}
+}
+
+// newInlineSubscriber creates a subscriber whose handler runs in the read loop.
+func newInlineSubscriber[T any](handler func(T)) *subscriber[T] {
+	return &subscriber[T]{handler: handler, inline: true, done: make(chan struct{})}
+}
+
+// terminate ends the subscriber with err and closes its channel. Caller must hold the stream lock.
+func (sub *subscriber[T]) terminate(err error) {
+	select {
+	case <-sub.done:
+		return
+	default:
+	}
+	sub.err = err
+	// Closing done first releases a blocked deliver, which holds sendMu
+	close(sub.done)
+	if sub.ch != nil {
+		sub.sendMu.Lock()
+		close(sub.ch)
+		sub.sendMu.Unlock()
+	}
+}
+
+// stream holds the local consumers of one upstream subscription (channel + instID).
+type stream[T any] struct {
+	client   atomic.Pointer[Client] // connection carrying the stream; changed when a Pool moves it
+	channel  string
+	instID   string
+	ready    chan struct{} // closed once the upstream subscribe is acknowledged or rejected
+	readyErr error
+
+	mu   sync.Mutex // guards subs and subscriber termination
+	subs []*subscriber[T]
+}
+
+// streamEntry is the type-erased view of a stream used by the read loop and reconnect.
+type streamEntry interface {
+	// args returns the upstream subscription arguments.
+	args() (channel, instID string)
+	// dispatch decodes a raw push message and delivers it to all subscribers.
+	dispatch(raw []byte) error
+	// terminate ends all subscribers with err.
+	terminate(err error)
+	// settled reports whether the upstream subscribe has been acknowledged.
+	settled() bool
+	// setClient moves the stream to another connection.
+	setClient(c *Client)
+}
+
+func (s *stream[T]) args() (string, string) {
+	return s.channel, s.instID
+}
+
+func (s *stream[T]) dispatch(raw []byte) error {
+	var msg T
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	subs := slices.Clone(s.subs)
+	s.mu.Unlock()
+	for _, sub := range subs {
+		if sub.inline {
+			select {
+			case <-sub.done:
+			default:
+				sub.handler(msg)
+			}
+			continue
+		}
+		if !sub.deliver(msg, s.client.Load().ctx.Done()) && sub.evicting.CompareAndSwap(false, true) {
+			go s.disconnect(sub)
+		}
+	}
+	return nil
+}
+
+// disconnect ends a subscriber that overflowed with OverflowDisconnect.
+func (s *stream[T]) disconnect(sub *subscriber[T]) {
+	c, p, err := s.remove(sub, ErrSlowConsumer)
+	if err == nil && p != nil {
+		c.awaitOp(context.Background(), p)
+	}
+}
+
+// remove terminates sub with err and detaches it from the stream. If it was the last consumer
+// the stream is dropped and the upstream unsubscribe is sent on the returned client;
+// the returned pendingOp awaits its ack.
+func (s *stream[T]) remove(sub *subscriber[T], err error) (*Client, *pendingOp, error) {
+	c := s.lockClient()
+	defer c.opMu.Unlock()
+	key := streamKey(s.channel, s.instID)
+	c.mu.Lock()
+	s.mu.Lock()
+	removed := false
+	for i, q := range s.subs {
+		if q == sub {
+			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			sub.terminate(err)
+			removed = true
+			break
+		}
+	}
+	last := removed && len(s.subs) == 0
+	s.mu.Unlock()
+	if last && c.streams[key] == s {
+		delete(c.streams, key)
+		defer c.released()
+	}
+	c.mu.Unlock()
+
+	if last && c.currentConn() != nil {
+		p, err := c.startOp(OpUnsubscribe, s.channel, s.instID)
+		return c, p, err
+	}
+	return c, nil, nil
+}
+
+// lockClient locks opMu of the client carrying the stream and returns the client.
+// Holding opMu keeps the stream on that client.
+func (s *stream[T]) lockClient() *Client {
+	for {
+		c := s.client.Load()
+		c.opMu.Lock()
+		if s.client.Load() == c {
+			return c
+		}
+		c.opMu.Unlock()
+	}
+}
+
+func (s *stream[T]) settled() bool {
+	select {
+	case <-s.ready:
+		return s.readyErr == nil
+	default:
+		return false
+	}
+}
+
+func (s *stream[T]) setClient(c *Client) {
+	s.client.Store(c)
+}
+
+func (s *stream[T]) terminate(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subs {
+		sub.terminate(err)
+	}
+	s.subs = nil
+}
+
+// streamKey returns the routing key of a push message or subscription.
+func streamKey(channel, instID string) string {
+	return channel + ":" + instID
+}
+
+// Subscription is the handle of a single consumer of a channel.
+// Each consumer has its own handler or channel; unsubscribing one does not affect others.
+type Subscription[T any] struct {
+	channel Channel[T]
+	instID  string
+	sub     *subscriber[T]
+	stream  *stream[T]
+}
+
+// C returns the message channel of a subscription created with SubscribeChan (nil for callbacks).
+// The channel is closed when the subscription ends.
+func (s *Subscription[T]) C() <-chan T {
+	if s.sub.handler != nil {
+		return nil
+	}
+	return s.sub.ch
+}
+
+// Dropped returns how many messages were discarded because the subscription's buffer was full.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.sub.dropped.Load()
+}
+
+// Done returns a channel that is closed when the subscription ends.
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.sub.done
+}
+
+// Err returns why the subscription ended: a *SubscribeError if the server rejected it,
+// the connection error if the connection was lost without reconnect, ErrClosed after Client.Close,
+// ErrSlowConsumer if it overflowed with OverflowDisconnect.
+// It returns nil while the subscription is active or after Unsubscribe.
+func (s *Subscription[T]) Err() error {
+	s.stream.mu.Lock()
+	defer s.stream.mu.Unlock()
+	return s.sub.err
+}
+
+// Channel returns the channel name of the subscription.
+func (s *Subscription[T]) Channel() string {
+	return s.channel.Name
+}
+
+// InstID returns the instrument ID of the subscription.
+func (s *Subscription[T]) InstID() string {
+	return s.instID
+}
+
+// Unsubscribe removes this consumer. The server subscription is torn down when the last consumer leaves.
+func (s *Subscription[T]) Unsubscribe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), ackTimeout)
+	defer cancel()
+	return s.unsubscribe(ctx)
+}
+
+// unsubscribe removes this consumer and waits for the upstream unsubscribe ack if it was the last one.
+func (s *Subscription[T]) unsubscribe(ctx context.Context) error {
+	c, p, err := s.stream.remove(s.sub, nil)
+	if err != nil || p == nil {
+		return err
+	}
+	return c.awaitOp(ctx, p)
+}
+
+// attachment is a batch of subscriptions registered on one client and waiting for acknowledgements.
+type attachment[T any] struct {
+	client  *Client
+	handles []*Subscription[T]
+	created []bool       // the stream was created by this batch
+	ops     []*pendingOp // upstream subscribe requests of the created streams, in order
+	err     error        // error sending the requests
+}
+
+// attach registers a subscriber created by newSub for every instID and sends one batched subscribe
+// request (see writeOps) for the streams that are not subscribed yet. Later consumers of a stream
+// wait for the result of its first subscribe. wait must be called on the returned attachment.
+func attach[T any](c *Client, ch Channel[T], instIDs []string, newSub func() *subscriber[T]) (*attachment[T], error) {
+	if ch.Private && c.creds == nil {
+		return nil, errNotPrivate
+	}
+	c.opMu.Lock()
+	defer c.opMu.Unlock()
+	c.mu.Lock()
+	for _, instID := range instIDs {
+		key := streamKey(ch.Name, instID)
+		if entry, ok := c.streams[key]; ok {
+			if _, ok := entry.(*stream[T]); !ok {
+				c.mu.Unlock()
+				return nil, fmt.Errorf("channel %s is already subscribed with a different message type", key)
+			}
+		}
+	}
+
+	a := &attachment[T]{client: c}
+	var args []opArg
+	for _, instID := range instIDs {
+		key := streamKey(ch.Name, instID)
+		s, _ := c.streams[key].(*stream[T])
+		created := s == nil
+		if created {
+			s = &stream[T]{channel: ch.Name, instID: instID, ready: make(chan struct{})}
+			s.client.Store(c)
+			c.streams[key] = s
+			args = append(args, opArg{Channel: ch.Name, InstID: instID})
+		}
+		sub := newSub()
+		s.mu.Lock()
+		s.subs = append(s.subs, sub)
+		s.mu.Unlock()
+		if sub.handler != nil && !sub.inline {
+			go sub.run()
+		}
+		a.handles = append(a.handles, &Subscription[T]{channel: ch, instID: instID, sub: sub, stream: s})
+		a.created = append(a.created, created)
+	}
+	c.mu.Unlock()
+
+	if len(args) > 0 {
+		a.ops, a.err = c.startOps(OpSubscribe, args)
+	}
+	return a, nil
+}
+
+// wait waits for the subscribe results of the batch. If any subscription fails, all of them are
+// removed and the first error is returned. If the server rejects a subscription all consumers of that
+// stream are terminated with the *SubscribeError.
+func (a *attachment[T]) wait(ctx context.Context) ([]*Subscription[T], error) {
+	c := a.client
+	errs := make([]error, len(a.handles))
+	// Resolve created streams first: later consumers (possibly in this batch) wait on them
+	n := 0
+	for i, h := range a.handles {
+		if !a.created[i] {
+			continue
+		}
+		err := a.err
+		if err == nil && a.ops != nil {
+			err = c.awaitOp(ctx, a.ops[n])
+		}
+		n++
+		h.stream.readyErr = err
+		close(h.stream.ready)
+		errs[i] = err
+	}
+	for i, h := range a.handles {
+		if a.created[i] {
+			continue
+		}
+		select {
+		case <-h.stream.ready:
+			errs[i] = h.stream.readyErr
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+
+	var first error
+	for _, err := range errs {
+		if err != nil {
+			first = err
+			break
+		}
+	}
+	if first == nil {
+		return a.handles, nil
+	}
+	a.rollback(ctx, errs)
+	return nil, first
+}
+
+// rollback removes the subscriptions of the batch. errs holds the per-subscription results (may be nil).
+// The upstream unsubscribe is sent only for subscriptions that were acknowledged or may still be in
+// flight (ctx ended first); waiting for its ack is bounded by ctx. Rejected or unsent subscriptions
+// are dropped locally.
+func (a *attachment[T]) rollback(ctx context.Context, errs []error) {
+	for i, h := range a.handles {
+		var err error
+		if errs != nil {
+			err = errs[i]
+		}
+		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			h.stream.client.Load().dropStream(streamKey(h.channel.Name, h.instID), h.stream, err)
+			continue
+		}
+		h.unsubscribe(ctx)
+	}
+}
+
+// subscribe registers sub for ch and instID and waits for the subscribe result.
+func subscribe[T any](ctx context.Context, c *Client, ch Channel[T], instID string, sub *subscriber[T]) (*Subscription[T], error) {
+	a, err := attach(c, ch, []string{instID}, func() *subscriber[T] { return sub })
+	if err != nil {
+		return nil, err
+	}
+	handles, err := a.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handles[0], nil
+}
+
+// Subscribe subscribes to ch for instID and calls handler for each push message.
+// instID may be empty for private channels without instrument filter.
+// Calls are made one at a time in message order; opts override the client delivery policy.
+func Subscribe[T any](ctx context.Context, c *Client, ch Channel[T], instID string, handler func(T), opts ...SubscribeOption) (*Subscription[T], error) {
+	return subscribe(ctx, c, ch, instID, newSubscriber(handler, c.deliveryPolicy(opts)))
+}
+
+// SubscribeChan subscribes to ch for instID. Push messages are delivered to the channel returned by C.
+// Every call creates a new consumer with its own channel; opts override the client delivery policy.
+func SubscribeChan[T any](ctx context.Context, c *Client, ch Channel[T], instID string, opts ...SubscribeOption) (*Subscription[T], error) {
+	return subscribe(ctx, c, ch, instID, newSubscriber[T](nil, c.deliveryPolicy(opts)))
+}
+
+// Unsubscribe unsubscribes from ch for instID and ends all of its consumers.
+func Unsubscribe[T any](ctx context.Context, c *Client, ch Channel[T], instID string) error {
+	key := streamKey(ch.Name, instID)
+	c.opMu.Lock()
+	c.mu.Lock()
+	entry, ok := c.streams[key]
+	delete(c.streams, key)
+	c.mu.Unlock()
+	if ok {
+		entry.terminate(nil)
+		c.released()
+	}
+	var p *pendingOp
+	var err error
+	if c.currentConn() != nil {
+		p, err = c.startOp(OpUnsubscribe, ch.Name, instID)
+	}
+	c.opMu.Unlock()
+	if err != nil || p == nil {
+		return err
+	}
+	return c.awaitOp(ctx, p)
+}
+
+// dropStream removes the stream stored under key (if it is still s) and terminates its consumers with err.
+func (c *Client) dropStream(key string, s streamEntry, err error) {
+	c.mu.Lock()
+	dropped := c.streams[key] == s
+	if dropped {
+		delete(c.streams, key)
+	}
+	c.mu.Unlock()
+	s.terminate(err)
+	if dropped {
+		c.released()
+	}
+}
+
+// terminateStreams ends all streams with err. Used when the connection is lost for good or the client is closed.
+func (c *Client) terminateStreams(err error) {
+	c.mu.Lock()
+	entries := make([]streamEntry, 0, len(c.streams))
+	for _, entry := range c.streams {
+		entries = append(entries, entry)
+	}
+	c.streams = make(map[string]streamEntry)
+	c.mu.Unlock()
+	for _, entry := range entries {
+		entry.terminate(err)
+	}
+}
+
+// dispatch routes a raw push message to the stream registered for channel and instID.
+func (c *Client) dispatch(channel, instID string, raw []byte) {
+	c.mu.Lock()
+	entry := c.streams[streamKey(channel, instID)]
+	c.mu.Unlock()
+	if entry == nil {
+		return
+	}
+	// Messages that do not decode into the channel type are skipped and reported
+	if err := entry.dispatch(raw); err != nil {
+		c.reportError(fmt.Errorf("decode %s push: %w", streamKey(channel, instID), err))
+	}
+}
@@ -0,0 +1,134 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmavka/go-blofin/models"
+)
+
+// opRecorder records the requests received by a fake server.
+type opRecorder struct {
+	mu  sync.Mutex
+	ops []string
+}
+
+func (r *opRecorder) add(req testRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range req.Args {
+		r.ops = append(r.ops, req.Op+" "+req.arg(i).InstID)
+	}
+}
+
+func (r *opRecorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ops...)
+}
+
+func TestSubscribeRollback(t *testing.T) {
+	tests := []struct {
+		name    string
+		instID  string
+		wantErr func(error) bool
+		wantOps []string
+	}{
+		{
+			name:    "no ack before ctx ends",
+			instID:  "SLOW",
+			wantErr: func(err error) bool { return errors.Is(err, context.DeadlineExceeded) },
+			wantOps: []string{"subscribe SLOW", "unsubscribe SLOW"},
+		},
+		{
+			name:    "rejected",
+			instID:  "BAD",
+			wantErr: func(err error) bool { var e *SubscribeError; return errors.As(err, &e) },
+			wantOps: []string{"subscribe BAD"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rec opRecorder
+			url := newTestServer(t, func(c *testConn, req testRequest) {
+				rec.add(req)
+				switch req.arg(0).InstID {
+				case "SLOW":
+					// Never acknowledged
+				case "BAD":
+					c.send(map[string]any{"event": "error", "code": "60018", "msg": "doesn't exist"})
+				default:
+					ackAll(c, req)
+				}
+			})
+			c := NewClient(url)
+			defer c.Close()
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			_, err := Subscribe(ctx, c, TradesChannel, tt.instID, func(models.WSTradeMsg) {})
+			if !tt.wantErr(err) {
+				t.Fatalf("err = %v", err)
+			}
+			if d := time.Since(start); d > time.Second {
+				t.Errorf("Subscribe returned after %v", d)
+			}
+			if n := c.streamCount(); n != 0 {
+				t.Errorf("%d streams left", n)
+			}
+			waitFor(t, "requests", func() bool { return len(rec.list()) >= len(tt.wantOps) })
+			time.Sleep(20 * time.Millisecond)
+			if got := rec.list(); len(got) != len(tt.wantOps) || got[len(got)-1] != tt.wantOps[len(tt.wantOps)-1] {
+				t.Errorf("requests = %v, want %v", got, tt.wantOps)
+			}
+		})
+	}
+}
+
+func TestDispatchReportsDecodeErrors(t *testing.T) {
+	url := newTestServer(t, func(c *testConn, req testRequest) {
+		ackAll(c, req)
+		if req.Op == OpSubscribe {
+			arg := req.arg(0)
+			c.send(map[string]any{"arg": arg, "data": [][]string{{"1", "not a price", "2", "buy", "0"}}})
+			c.send(map[string]any{"arg": arg, "data": [][]string{{"2", "100", "2", "buy", "0"}}})
+		}
+	})
+	c := NewClient(url)
+	defer c.Close()
+	errs := make(chan error, 10)
+	c.SetErrorHandler(func(err error) { errs <- err })
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	sub, err := SubscribeChan(context.Background(), c, TradesChannel, "BTC-USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "trades:BTC-USDT") {
+			t.Errorf("err = %v, want the channel and instId", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("decode error not reported")
+	}
+	// Later messages are still delivered
+	select {
+	case msg := <-sub.C():
+		if len(msg.Data) != 1 || msg.Data[0].TradeID != "2" {
+			t.Errorf("msg = %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("message not delivered")
+	}
+}